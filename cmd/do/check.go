@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/jibaru/do/internal/checker"
+	"github.com/jibaru/do/internal/env"
+	"github.com/jibaru/do/internal/reader"
+)
+
+// runCheck validates .do files without executing the requests
+func runCheck(args []string) int {
+	var envPath string
+
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: do check [flags] [path ...]")
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&envPath, "env", "", "Path to the env file (optional)")
+	flags.StringVar(&envPath, "e", "", "Path to the env file (optional)")
+	_ = flags.Parse(args)
+
+	if envPath != "" {
+		if err := env.ParseAndSet(envPath); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			return 2
+		}
+	}
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	filenames, err := reader.FindDoFiles(paths...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 2
+	}
+
+	p := newPipeline()
+	c := checker.New(p.doFileReader, p.commentCleaner, p.sectionExtractor, p.parser)
+
+	total := 0
+	for _, filename := range filenames {
+		for _, problem := range c.Check(filename) {
+			fmt.Println(problem.String())
+			total++
+		}
+	}
+
+	fmt.Printf("%d problem(s) found in %d file(s)\n", total, len(filenames))
+	if total > 0 {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/jibaru/do/internal/cookies"
+)
+
+// runCookies shows or removes the cookies kept by a cookie jar
+func runCookies(args []string) int {
+	usage := "Usage: do cookies <list|clear> (--session name | --cookie-jar path/to/jar)"
+	if len(args) == 0 || (args[0] != "list" && args[0] != "clear") {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+
+	var jarPath, session string
+
+	flags := flag.NewFlagSet("cookies "+args[0], flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), usage)
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&jarPath, "cookie-jar", "", "Path to the cookie jar")
+	flags.StringVar(&session, "session", "", "Name of the session")
+	_ = flags.Parse(args[1:])
+
+	jar, err := openJar(jarPath, session)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 1
+	}
+	if jar == nil {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+
+	if args[0] == "clear" {
+		jar.Clear()
+		if err = jar.Save(); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			return 1
+		}
+		return 0
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "DOMAIN\tPATH\tNAME\tVALUE\tEXPIRES\tSECURE\tHTTP ONLY")
+	for _, cookie := range jar.All() {
+		expires := "session"
+		if cookie.Expires != nil {
+			expires = cookie.Expires.Format("2006-01-02T15:04:05Z")
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%t\t%t\n",
+			cookie.Domain, cookie.Path, cookie.Name, cookie.Value, expires, cookie.Secure, cookie.HttpOnly)
+	}
+	_ = writer.Flush()
+
+	return 0
+}
+
+// openJar returns the cookie jar at the path, or the one of the named session.
+// It returns nil when neither of them is given.
+func openJar(path, session string) (cookies.Jar, error) {
+	if path == "" && session != "" {
+		var err error
+		if path, err = cookies.SessionPath(session); err != nil {
+			return nil, err
+		}
+	}
+
+	if path == "" {
+		return nil, nil
+	}
+
+	return cookies.New(path)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jibaru/do/internal/env"
+	"github.com/jibaru/do/internal/exporter"
+)
+
+// exporters defines the formats available for do export
+var exporters = map[string]func() exporter.Exporter{
+	"curl": exporter.NewCurl,
+	"code": nil,
+}
+
+// codeExporters defines the languages available for do export code
+var codeExporters = map[string]func() exporter.Exporter{
+	"go":         exporter.NewGo,
+	"java":       exporter.NewJava,
+	"javascript": exporter.NewJavaScript,
+	"python":     exporter.NewPython,
+}
+
+// runExport renders the resolved request of a .do file in another format
+func runExport(args []string) int {
+	formats := sortedNames(exporters)
+
+	usage := "Usage: do export <" + strings.Join(formats, "|") + "> -f path/to/do/file [flags]"
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+	if _, ok := exporters[args[0]]; !ok {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+
+	var filename, envPath, language string
+
+	flags := flag.NewFlagSet("export "+args[0], flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), usage)
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&filename, "file", "", "Path to the do file (required)")
+	flags.StringVar(&filename, "f", "", "Path to the do file (required)")
+	flags.StringVar(&envPath, "env", "", "Path to the env file (optional)")
+	flags.StringVar(&envPath, "e", "", "Path to the env file (optional)")
+	if args[0] == "code" {
+		flags.StringVar(&language, "lang", "", "Language of the code: "+strings.Join(sortedNames(codeExporters), ", ")+" (required)")
+	}
+	_ = flags.Parse(args[1:])
+
+	newExporter := exporters[args[0]]
+	if args[0] == "code" {
+		if newExporter = codeExporters[language]; newExporter == nil {
+			fmt.Fprintln(os.Stderr, "unsupported language "+strconv.Quote(language)+", use one of: "+strings.Join(sortedNames(codeExporters), ", "))
+			return 2
+		}
+	}
+
+	if envPath != "" {
+		if err := env.ParseAndSet(envPath); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			return 1
+		}
+	}
+
+	doFile, err := newPipeline().parser.ParseFromFilename(filename)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 1
+	}
+
+	exported, err := newExporter().Export(*doFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 1
+	}
+
+	fmt.Println(exported)
+	return 0
+}
+
+// sortedNames returns the keys of the exporters map sorted
+func sortedNames(m map[string]func() exporter.Exporter) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jibaru/do/internal/importer"
+)
+
+// importers defines the formats available for do import
+var importers = map[string]func() importer.Importer{
+	"curl":    importer.NewCurl,
+	"har":     importer.NewHAR,
+	"http":    importer.NewHTTP,
+	"openapi": importer.NewOpenAPI,
+	"postman": importer.NewPostman,
+}
+
+// runImport converts a file in another format into .do files
+func runImport(args []string) int {
+	formats := make([]string, 0, len(importers))
+	for format := range importers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+
+	usage := "Usage: do import <" + strings.Join(formats, "|") + "> [flags] [path/to/input]"
+	if len(args) == 0 || importers[args[0]] == nil {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+
+	var output string
+
+	flags := flag.NewFlagSet("import "+args[0], flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), usage)
+		fmt.Fprintln(flags.Output(), "The input is read from stdin when no path is given.")
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&output, "output", "", "Path to the output file or directory (optional, stdout by default)")
+	flags.StringVar(&output, "o", "", "Path to the output file or directory (optional, stdout by default)")
+	_ = flags.Parse(args[1:])
+
+	var content []byte
+	var err error
+	if flags.NArg() > 0 {
+		content, err = os.ReadFile(flags.Arg(0))
+	} else {
+		content, err = io.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 1
+	}
+
+	result, err := importers[args[0]]().Import(content)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 1
+	}
+
+	for _, warning := range result.Warnings {
+		fmt.Fprintln(os.Stderr, "warning: "+warning)
+	}
+
+	if err = writeImported(result.Files, output); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 1
+	}
+
+	return 0
+}
+
+// writeImported writes the files into the output. A single file is written to stdout when there
+// is no output, or to the output when it is not a directory.
+func writeImported(files []importer.File, output string) error {
+	if len(files) == 1 && output == "" {
+		fmt.Print(files[0].Content)
+		return nil
+	}
+
+	if output == "" {
+		return fmt.Errorf("the output directory is required to import %d files", len(files))
+	}
+
+	if info, err := os.Stat(output); len(files) == 1 && (err != nil || !info.IsDir()) && filepath.Ext(output) != "" {
+		return os.WriteFile(output, []byte(files[0].Content), 0644)
+	}
+
+	for _, file := range files {
+		path := filepath.Join(output, filepath.FromSlash(file.Path))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(path, []byte(file.Content), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
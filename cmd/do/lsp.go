@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/jibaru/do/internal/checker"
+	"github.com/jibaru/do/internal/env"
+	"github.com/jibaru/do/internal/formatter"
+	"github.com/jibaru/do/internal/lsp"
+)
+
+// runLsp starts the language server over stdin and stdout
+func runLsp(args []string) int {
+	var envPath string
+
+	flags := flag.NewFlagSet("lsp", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: do lsp [flags]")
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&envPath, "env", "", "Path to the env file (optional)")
+	flags.StringVar(&envPath, "e", "", "Path to the env file (optional)")
+	_ = flags.Parse(args)
+
+	if envPath != "" {
+		if err := env.ParseAndSet(envPath); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			return 2
+		}
+	}
+
+	p := newPipeline()
+	c := checker.New(p.doFileReader, p.commentCleaner, p.sectionExtractor, p.parser)
+	server := lsp.New(c, p.parser, formatter.New(), Version)
+
+	if err := server.Serve(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 1
+	}
+
+	return 0
+}
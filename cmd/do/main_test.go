@@ -0,0 +1,117 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jibaru/do/internal/assertion"
+	"github.com/jibaru/do/internal/capture"
+	"github.com/jibaru/do/internal/store"
+	"github.com/jibaru/do/internal/types"
+)
+
+func TestRun_MasksSecrets(t *testing.T) {
+	const secret = "s3cr3t-value"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"token": "` + secret + `"}`))
+	}))
+	defer server.Close()
+
+	directory, _ := os.Getwd()
+	defer func() { _ = os.Chdir(directory) }()
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+
+	values := store.New(store.Path)
+	if err := values.Set("token", secret, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := values.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"stored.do": `let {
+    token = store("token");
+}
+
+do {
+    method = "POST";
+    url = "` + server.URL + `/orders?token=$token";
+    headers = {"Authorization": "Bearer $token"};
+    body = "{\"token\": \"$token\"}";
+}
+
+expect {
+    json = {"$.token": "$token"};
+}`,
+		"login.do": `do {
+    method = "POST";
+    url = "` + server.URL + `/login";
+}
+
+capture {
+    token = "json:$.token";
+}
+
+expect {
+    json = {"$.token": "other"};
+    contains = {"$.token": "s3cr3t"};
+}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := []struct {
+		name      string
+		filenames []string
+	}{
+		{name: "success value read from the store", filenames: []string{"stored.do"}},
+		{name: "success captured value", filenames: []string{"login.do"}},
+		{name: "success captured value used by the next file", filenames: []string{"login.do", "stored.do"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			thePipeline := newPipeline()
+			variables := make(types.Map)
+			outputs := make(types.CommandLineOutputs, 0, len(tc.filenames))
+
+			for _, filename := range tc.filenames {
+				output := run(thePipeline.parser.WithVariables(variables), capture.New(), assertion.New(), thePipeline.values, filename, params{harPath: "requests.har"})
+				if output.Error != nil {
+					t.Fatalf("expected no error, got %v", *output.Error)
+				}
+				if len(output.Assertions) == 0 {
+					t.Fatalf("expected assertions, got none")
+				}
+				outputs = append(outputs, *output)
+
+				for key, value := range output.Captures {
+					variables[key] = value
+				}
+			}
+
+			if printed := outputs.MarshalIndent(); strings.Contains(printed, secret) {
+				t.Errorf("expected the secret to be masked, got %s", printed)
+			}
+
+			archive, err := os.ReadFile("requests.har")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(string(archive), secret) {
+				t.Errorf("expected the secret to be masked in the HAR file, got %s", archive)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jibaru/do/internal/store"
+	"github.com/jibaru/do/internal/types"
+)
+
+// runStore shows, changes or removes the values kept in the store
+func runStore(args []string) int {
+	usage := "Usage: do store list [--reveal] | get <name> | set [--ttl 1h] <name> <value> | clear [name...]"
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+
+	var reveal bool
+	var ttl time.Duration
+
+	flags := flag.NewFlagSet("store "+args[0], flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), usage)
+		flags.PrintDefaults()
+	}
+	switch args[0] {
+	case "list":
+		flags.BoolVar(&reveal, "reveal", false, "Show the values instead of masking them")
+	case "set":
+		flags.DurationVar(&ttl, "ttl", 0, "Time the value is kept, it does not expire by default")
+	case "get", "clear":
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+	_ = flags.Parse(args[1:])
+
+	values := store.New(store.Path)
+	var err error
+
+	switch args[0] {
+	case "list":
+		err = listStore(values, reveal)
+	case "get":
+		if flags.NArg() != 1 {
+			fmt.Fprintln(os.Stderr, usage)
+			return 2
+		}
+
+		value, found, getErr := values.Get(flags.Arg(0))
+		if getErr == nil && !found {
+			fmt.Fprintln(os.Stderr, "value "+flags.Arg(0)+" not found")
+			return 1
+		}
+		if getErr == nil {
+			fmt.Println(value)
+		}
+		err = getErr
+	case "set":
+		if flags.NArg() != 2 {
+			fmt.Fprintln(os.Stderr, usage)
+			return 2
+		}
+
+		if err = values.Set(flags.Arg(0), types.String(flags.Arg(1)), ttl); err == nil {
+			err = values.Save()
+		}
+	case "clear":
+		err = clearStore(values, flags.Args())
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 1
+	}
+
+	return 0
+}
+
+// listStore prints the values of the store, masked unless they are revealed
+func listStore(values store.Store, reveal bool) error {
+	entries, err := values.All()
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "NAME\tVALUE\tEXPIRES")
+	for _, entry := range entries {
+		value := fmt.Sprintf("%v", entry.Value)
+		if !reveal {
+			value = types.Mask(value)
+		}
+
+		expires := "never"
+		if entry.ExpiresAt != nil {
+			expires = entry.ExpiresAt.Format("2006-01-02T15:04:05Z")
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", entry.Name, value, expires)
+	}
+
+	return writer.Flush()
+}
+
+// clearStore removes the values with the names, or every value when no name is given
+func clearStore(values store.Store, names []string) error {
+	if len(names) == 0 {
+		values.Clear()
+		return values.Save()
+	}
+
+	for _, name := range names {
+		found, err := values.Delete(name)
+		if err != nil {
+			return err
+		}
+		if !found {
+			fmt.Fprintln(os.Stderr, "value "+name+" not found")
+		}
+	}
+
+	return values.Save()
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jibaru/do/internal/assertion"
+	"github.com/jibaru/do/internal/capture"
+	"github.com/jibaru/do/internal/env"
+	"github.com/jibaru/do/internal/reader"
+	"github.com/jibaru/do/internal/report"
+	"github.com/jibaru/do/internal/snapshot"
+	"github.com/jibaru/do/internal/types"
+	"github.com/jibaru/do/internal/utils"
+)
+
+// runTest sends the .do files of the paths as tests, printing the result of each file and a summary
+func runTest(args []string) int {
+	var envPath, junitPath, tapPath, reportPath string
+	var concurrency int
+	var updateSnapshots bool
+
+	flags := flag.NewFlagSet("test", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: do test [flags] [path ...]")
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&envPath, "env", "", "Path to the env file (optional)")
+	flags.StringVar(&envPath, "e", "", "Path to the env file (optional)")
+	flags.IntVar(&concurrency, "concurrency", 1, "Number of files sent at the same time (optional)")
+	flags.StringVar(&junitPath, "junit", "", "Path to write a JUnit XML report (optional)")
+	flags.StringVar(&tapPath, "tap", "", "Path to write a TAP report (optional)")
+	flags.StringVar(&reportPath, "report", "", "Path to write an HTML report (optional)")
+	flags.BoolVar(&updateSnapshots, "update-snapshots", false, "Write the responses to the snapshots instead of comparing them (optional)")
+	_ = flags.Parse(args)
+
+	if concurrency < 1 {
+		fmt.Fprintln(os.Stderr, "concurrency must be greater than zero")
+		return 2
+	}
+
+	if envPath != "" {
+		if err := env.ParseAndSet(envPath); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			return 2
+		}
+	}
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	filenames, err := reader.FindDoFiles(paths...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 2
+	}
+
+	startedAt := time.Now()
+	results := sendTests(filenames, concurrency, updateSnapshots, printResult)
+
+	passed, updated, compared, notCompared := 0, 0, 0, 0
+	for _, result := range results {
+		if result.Passed() {
+			passed++
+		}
+		if result.Output.Snapshot != nil && result.Output.Snapshot.Updated {
+			updated++
+		}
+		if result.Output.Snapshot != nil {
+			compared++
+		} else if result.Output.Error == nil {
+			notCompared++
+		}
+	}
+	if updateSnapshots {
+		fmt.Printf("%d snapshot(s) written\n", updated)
+	} else if compared > 0 && notCompared > 0 {
+		// a golden file that was deleted or never committed would check nothing without a notice
+		fmt.Printf("%d file(s) without snapshot, not compared\n", notCompared)
+	}
+	fmt.Printf("%d passed, %d failed, %d total in %v\n", passed, len(results)-passed, len(results), time.Since(startedAt).Round(time.Millisecond))
+
+	reports := []struct {
+		path     string
+		reporter report.Reporter
+	}{
+		{path: junitPath, reporter: report.NewJUnit()},
+		{path: tapPath, reporter: report.NewTAP()},
+		{path: reportPath, reporter: report.NewHTML()},
+	}
+	for _, r := range reports {
+		if err = writeReport(r.path, r.reporter, results); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			return 2
+		}
+	}
+
+	if passed < len(results) {
+		return 1
+	}
+
+	return 0
+}
+
+// sendTests sends the files with up to concurrency files at the same time, comparing the responses
+// with their snapshots or writing them when updateSnapshots is true. The files do not share variables,
+// the values captured by a file are read by the others with the store function. The done function is
+// called with each result once it is ready, and the results are returned in file order.
+func sendTests(
+	filenames []string,
+	concurrency int,
+	updateSnapshots bool,
+	done func(result types.TestResult),
+) []types.TestResult {
+	thePipeline := newPipeline()
+	capturer := capture.New()
+	asserter := assertion.New()
+	snapshotter := snapshot.New()
+
+	results := make([]types.TestResult, len(filenames))
+	slots := make(chan struct{}, concurrency)
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+
+	for i, filename := range filenames {
+		slots <- struct{}{}
+		wg.Add(1)
+
+		go func(i int, filename string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			startedAt := time.Now()
+			output := run(thePipeline.parser, capturer, asserter, thePipeline.values, filename, params{})
+			checkSnapshot(snapshotter, filename, output, updateSnapshots)
+			results[i] = types.TestResult{
+				Filename:  filename,
+				StartedAt: startedAt,
+				Duration:  float64(time.Since(startedAt).Microseconds()) / 1000,
+				Output:    output.Masked(),
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			done(results[i])
+		}(i, filename)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// checkSnapshot compares the response of the file with its snapshot, or writes it when update is true.
+// Nothing is done when the request failed.
+func checkSnapshot(snapshotter snapshot.Snapshotter, filename string, output *types.CommandLineOutput, update bool) {
+	if output.Error != nil || output.Response == nil {
+		return
+	}
+
+	var err error
+	if update {
+		output.Snapshot, err = snapshotter.Update(filename, output.DoFile.Snapshot, *output.Response)
+	} else {
+		output.Snapshot, err = snapshotter.Compare(filename, output.DoFile.Snapshot, *output.Response)
+	}
+
+	if err != nil {
+		output.Error = utils.Ptr(err.Error())
+	}
+}
+
+// writeReport writes the results rendered by the reporter to the file at path, it does nothing when path is empty
+func writeReport(path string, reporter report.Reporter, results []types.TestResult) error {
+	if path == "" {
+		return nil
+	}
+
+	content, err := reporter.Report(results)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0644)
+}
+
+// printResult prints whether the file passed, with the failures when it did not
+func printResult(result types.TestResult) {
+	status := "PASS"
+	if !result.Passed() {
+		status = "FAIL"
+	}
+
+	fmt.Printf("%s %s (%v)\n", status, result.Filename, time.Duration(result.Duration*float64(time.Millisecond)).Round(time.Millisecond))
+	for _, failure := range result.Failures() {
+		fmt.Println("    " + strings.ReplaceAll(failure, "\n", "\n    "))
+	}
+}
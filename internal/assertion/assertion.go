@@ -0,0 +1,214 @@
+package assertion
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jibaru/do/internal/jsonpath"
+	"github.com/jibaru/do/internal/types"
+)
+
+type Asserter interface {
+	// Assert returns the result of each expectation checked against the request sent and its response
+	Assert(expectations []types.Expectation, request types.Request, response types.Response) []types.Assertion
+}
+
+type asserter struct{}
+
+func New() Asserter {
+	return &asserter{}
+}
+
+func (a *asserter) Assert(expectations []types.Expectation, request types.Request, response types.Response) []types.Assertion {
+	assertions := make([]types.Assertion, 0, len(expectations))
+
+	for _, expectation := range expectations {
+		assertion := types.Assertion{
+			Type:     expectation.Type,
+			Target:   expectation.Target,
+			Expected: expectation.Value,
+		}
+
+		switch expectation.Type {
+		case types.ExpectStatus:
+			a.status(&assertion, response)
+		case types.ExpectHeaders:
+			a.header(&assertion, response)
+		case types.ExpectJSON, types.ExpectContains, types.ExpectMatches:
+			a.json(&assertion, response)
+		case types.ExpectMaxTime:
+			a.maxTime(&assertion, request)
+		case types.ExpectMaxSize:
+			a.maxSize(&assertion, response)
+		default:
+			assertion.Message = "unknown expectation " + expectation.Type
+		}
+
+		assertions = append(assertions, assertion)
+	}
+
+	return assertions
+}
+
+func (a *asserter) status(assertion *types.Assertion, response types.Response) {
+	assertion.Actual = types.Int(response.StatusCode)
+	assertion.Passed = reflect.DeepEqual(assertion.Actual, assertion.Expected)
+	if !assertion.Passed {
+		assertion.Message = fmt.Sprintf("expected status %v, got %v", assertion.Expected, assertion.Actual)
+	}
+}
+
+// header passes when any value of the header, whose name is compared in any case, is the expected one
+func (a *asserter) header(assertion *types.Assertion, response types.Response) {
+	for key, value := range response.Headers {
+		values, ok := value.([]string)
+		if !ok || !strings.EqualFold(key, assertion.Target) {
+			continue
+		}
+
+		assertion.Actual = types.String(strings.Join(values, ", "))
+		for _, val := range values {
+			if types.String(val) == assertion.Expected {
+				assertion.Passed = true
+			}
+		}
+	}
+
+	if assertion.Actual == nil {
+		assertion.Message = "header " + assertion.Target + " not found"
+	} else if !assertion.Passed {
+		assertion.Message = fmt.Sprintf("expected header %s to be %s, got %s", assertion.Target, text(assertion.Expected), text(assertion.Actual))
+	}
+}
+
+// json reads the value of the json path and checks it is equal to, contains or matches the expected one
+func (a *asserter) json(assertion *types.Assertion, response types.Response) {
+	actual, err := jsonpath.Get([]byte(response.Body), assertion.Target)
+	if err != nil {
+		assertion.Message = err.Error()
+		return
+	}
+	assertion.Actual = actual
+
+	switch assertion.Type {
+	case types.ExpectJSON:
+		assertion.Passed = equal(actual, assertion.Expected)
+		if !assertion.Passed {
+			assertion.Message = fmt.Sprintf("expected %s to be %s, got %s", assertion.Target, text(assertion.Expected), text(actual))
+		}
+	case types.ExpectContains:
+		assertion.Passed = contains(actual, assertion.Expected)
+		if !assertion.Passed {
+			assertion.Message = fmt.Sprintf("expected %s to contain %s, got %s", assertion.Target, text(assertion.Expected), text(actual))
+		}
+	case types.ExpectMatches:
+		value, ok := actual.(string)
+		if !ok {
+			value = text(actual)
+		}
+
+		re, err := regexp.Compile(fmt.Sprintf("%v", assertion.Expected))
+		if err != nil {
+			assertion.Message = err.Error()
+			return
+		}
+
+		assertion.Passed = re.MatchString(value)
+		if !assertion.Passed {
+			assertion.Message = fmt.Sprintf("expected %s to match %s, got %s", assertion.Target, text(assertion.Expected), text(actual))
+		}
+	}
+}
+
+// maxTime passes when the last attempt to send the request took at most the expected duration
+func (a *asserter) maxTime(assertion *types.Assertion, request types.Request) {
+	if len(request.Attempts) == 0 {
+		assertion.Message = "response time not found"
+		return
+	}
+
+	milliseconds := request.Attempts[len(request.Attempts)-1].Duration
+	actual := types.Duration(time.Duration(milliseconds * float64(time.Millisecond)).Round(time.Millisecond))
+	assertion.Actual = actual
+
+	expected, _ := assertion.Expected.(types.Duration)
+	assertion.Passed = actual <= expected
+	if !assertion.Passed {
+		assertion.Message = fmt.Sprintf("expected a response time up to %v, got %v", time.Duration(expected), time.Duration(actual))
+	}
+}
+
+// maxSize passes when the body has at most the expected number of bytes
+func (a *asserter) maxSize(assertion *types.Assertion, response types.Response) {
+	actual := types.Int(len(response.Body))
+	assertion.Actual = actual
+
+	expected, _ := assertion.Expected.(types.Int)
+	assertion.Passed = actual <= expected
+	if !assertion.Passed {
+		assertion.Message = fmt.Sprintf("expected a body size up to %v bytes, got %v", expected, actual)
+	}
+}
+
+// equal returns true when both values have the same json representation, comparing numbers by value
+func equal(actual, expected interface{}) bool {
+	return reflect.DeepEqual(normalize(actual), normalize(expected))
+}
+
+// contains returns true when the string has the expected substring, the array has an element equal
+// to the expected value, or the object has the keys and values of the expected map
+func contains(actual, expected interface{}) bool {
+	switch val := normalize(actual).(type) {
+	case string:
+		substring, ok := normalize(expected).(string)
+		return ok && strings.Contains(val, substring)
+	case []interface{}:
+		for _, element := range val {
+			if equal(element, expected) {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		fields, ok := normalize(expected).(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, field := range fields {
+			if value, ok := val[key]; !ok || !equal(value, field) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+// normalize returns the value decoded from its json representation
+func normalize(value interface{}) interface{} {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	var normalized interface{}
+	if err = json.Unmarshal(content, &normalized); err != nil {
+		return value
+	}
+
+	return normalized
+}
+
+// text returns the json representation of the value, used in the messages
+func text(value interface{}) string {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(content)
+}
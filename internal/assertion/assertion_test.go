@@ -0,0 +1,109 @@
+package assertion_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jibaru/do/internal/assertion"
+	"github.com/jibaru/do/internal/types"
+)
+
+func TestAsserter_Assert(t *testing.T) {
+	request := types.Request{
+		Attempts: []types.Attempt{{Duration: 950}, {Duration: 120.4}},
+	}
+	response := types.Response{
+		StatusCode: 201,
+		Body:       `{"data": {"id": 12, "name": "john", "email": "john@example.com", "roles": ["read", "write"], "meta": {"a": 1, "b": true}}}`,
+		Headers:    map[string]interface{}{"Content-Type": []string{"application/json"}},
+	}
+
+	testCases := []struct {
+		name         string
+		body         string
+		expectations []types.Expectation
+		expected     []types.Assertion
+	}{
+		{
+			name: "success passed",
+			expectations: []types.Expectation{
+				{Type: types.ExpectStatus, Value: types.Int(201)},
+				{Type: types.ExpectHeaders, Target: "content-type", Value: types.String("application/json")},
+				{Type: types.ExpectJSON, Target: "$.data.id", Value: types.Float(12)},
+				{Type: types.ExpectJSON, Target: "$.data.meta", Value: types.Map{"a": types.Int(1), "b": types.Bool(true)}},
+				{Type: types.ExpectContains, Target: "$.data.roles", Value: types.String("write")},
+				{Type: types.ExpectContains, Target: "$.data.name", Value: types.String("oh")},
+				{Type: types.ExpectContains, Target: "$.data.meta", Value: types.Map{"b": types.Bool(true)}},
+				{Type: types.ExpectMatches, Target: "$.data.email", Value: types.String(`^\w+@example\.com$`)},
+				{Type: types.ExpectMaxTime, Value: types.Duration(500 * time.Millisecond)},
+				{Type: types.ExpectMaxSize, Value: types.Int(1024)},
+			},
+			expected: []types.Assertion{
+				{Type: types.ExpectStatus, Expected: types.Int(201), Actual: types.Int(201), Passed: true},
+				{Type: types.ExpectHeaders, Target: "content-type", Expected: types.String("application/json"), Actual: types.String("application/json"), Passed: true},
+				{Type: types.ExpectJSON, Target: "$.data.id", Expected: types.Float(12), Actual: json.Number("12"), Passed: true},
+				{Type: types.ExpectJSON, Target: "$.data.meta", Expected: types.Map{"a": types.Int(1), "b": types.Bool(true)}, Actual: map[string]interface{}{"a": json.Number("1"), "b": true}, Passed: true},
+				{Type: types.ExpectContains, Target: "$.data.roles", Expected: types.String("write"), Actual: []interface{}{"read", "write"}, Passed: true},
+				{Type: types.ExpectContains, Target: "$.data.name", Expected: types.String("oh"), Actual: "john", Passed: true},
+				{Type: types.ExpectContains, Target: "$.data.meta", Expected: types.Map{"b": types.Bool(true)}, Actual: map[string]interface{}{"a": json.Number("1"), "b": true}, Passed: true},
+				{Type: types.ExpectMatches, Target: "$.data.email", Expected: types.String(`^\w+@example\.com$`), Actual: "john@example.com", Passed: true},
+				{Type: types.ExpectMaxTime, Expected: types.Duration(500 * time.Millisecond), Actual: types.Duration(120 * time.Millisecond), Passed: true},
+				{Type: types.ExpectMaxSize, Expected: types.Int(1024), Actual: types.Int(len(response.Body)), Passed: true},
+			},
+		},
+		{
+			name: "success failed",
+			expectations: []types.Expectation{
+				{Type: types.ExpectStatus, Value: types.Int(200)},
+				{Type: types.ExpectHeaders, Target: "Content-Type", Value: types.String("text/html")},
+				{Type: types.ExpectHeaders, Target: "ETag", Value: types.String("abc")},
+				{Type: types.ExpectJSON, Target: "$.data.name", Value: types.String("jane")},
+				{Type: types.ExpectJSON, Target: "$.data.age", Value: types.Int(30)},
+				{Type: types.ExpectContains, Target: "$.data.roles", Value: types.String("admin")},
+				{Type: types.ExpectMatches, Target: "$.data.id", Value: types.String(`^\d{3}$`)},
+				{Type: types.ExpectMaxTime, Value: types.Duration(100 * time.Millisecond)},
+				{Type: types.ExpectMaxSize, Value: types.Int(10)},
+			},
+			expected: []types.Assertion{
+				{Type: types.ExpectStatus, Expected: types.Int(200), Actual: types.Int(201), Message: "expected status 200, got 201"},
+				{Type: types.ExpectHeaders, Target: "Content-Type", Expected: types.String("text/html"), Actual: types.String("application/json"), Message: `expected header Content-Type to be "text/html", got "application/json"`},
+				{Type: types.ExpectHeaders, Target: "ETag", Expected: types.String("abc"), Message: "header ETag not found"},
+				{Type: types.ExpectJSON, Target: "$.data.name", Expected: types.String("jane"), Actual: "john", Message: `expected $.data.name to be "jane", got "john"`},
+				{Type: types.ExpectJSON, Target: "$.data.age", Expected: types.Int(30), Message: "json path $.data.age not found"},
+				{Type: types.ExpectContains, Target: "$.data.roles", Expected: types.String("admin"), Actual: []interface{}{"read", "write"}, Message: `expected $.data.roles to contain "admin", got ["read","write"]`},
+				{Type: types.ExpectMatches, Target: "$.data.id", Expected: types.String(`^\d{3}$`), Actual: json.Number("12"), Message: `expected $.data.id to match "^\\d{3}$", got 12`},
+				{Type: types.ExpectMaxTime, Expected: types.Duration(100 * time.Millisecond), Actual: types.Duration(120 * time.Millisecond), Message: "expected a response time up to 100ms, got 120ms"},
+				{Type: types.ExpectMaxSize, Expected: types.Int(10), Actual: types.Int(len(response.Body)), Message: "expected a body size up to 10 bytes, got 122"},
+			},
+		},
+		{
+			name: "success failed with invalid json",
+			body: `{"id":`,
+			expectations: []types.Expectation{
+				{Type: types.ExpectJSON, Target: "$.id", Value: types.Int(1)},
+			},
+			expected: []types.Assertion{
+				{Type: types.ExpectJSON, Target: "$.id", Expected: types.Int(1), Message: "invalid json document: unexpected EOF"},
+			},
+		},
+	}
+
+	a := assertion.New()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			theResponse := response
+			if tc.body != "" {
+				theResponse.Body = tc.body
+			}
+
+			result := a.Assert(tc.expectations, request, theResponse)
+
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("expected %#v, got %#v", tc.expected, result)
+			}
+		})
+	}
+}
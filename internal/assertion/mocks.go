@@ -0,0 +1,11 @@
+package assertion
+
+import "github.com/jibaru/do/internal/types"
+
+type Mock struct {
+	AssertFn func(expectations []types.Expectation, request types.Request, response types.Response) []types.Assertion
+}
+
+func (m *Mock) Assert(expectations []types.Expectation, request types.Request, response types.Response) []types.Assertion {
+	return m.AssertFn(expectations, request, response)
+}
@@ -0,0 +1,108 @@
+package capture
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/jibaru/do/internal/jsonpath"
+	"github.com/jibaru/do/internal/types"
+)
+
+type Capturer interface {
+	// Capture returns the values of the response read by the captures, by the name of each capture
+	Capture(captures []types.Capture, response types.Response) (types.Map, error)
+}
+
+type capturer struct{}
+
+func New() Capturer {
+	return &capturer{}
+}
+
+func (c *capturer) Capture(captures []types.Capture, response types.Response) (types.Map, error) {
+	values := make(types.Map, len(captures))
+
+	for _, capture := range captures {
+		value, err := c.value(capture, response)
+		if err != nil {
+			return nil, NewCanNotCaptureError(capture.Name, err.Error())
+		}
+		values[capture.Name] = value
+	}
+
+	return values, nil
+}
+
+// value returns the value read by the capture as a basic type, so it can be used as a variable
+func (c *capturer) value(capture types.Capture, response types.Response) (interface{}, error) {
+	switch capture.Source {
+	case types.CaptureStatus:
+		return types.Int(response.StatusCode), nil
+	case types.CaptureBody:
+		return types.String(response.Body), nil
+	case types.CaptureHeader:
+		for key, value := range response.Headers {
+			if values, ok := value.([]string); ok && len(values) > 0 && strings.EqualFold(key, capture.Expression) {
+				return types.String(values[0]), nil
+			}
+		}
+		return nil, NewNotFoundError("header " + capture.Expression)
+	case types.CaptureCookie:
+		// the last cookie with the name is the one kept by the client
+		for i := len(response.Cookies) - 1; i >= 0; i-- {
+			if response.Cookies[i].Name == capture.Expression {
+				return types.String(response.Cookies[i].Value), nil
+			}
+		}
+		return nil, NewNotFoundError("cookie " + capture.Expression)
+	case types.CaptureRegex:
+		re, err := regexp.Compile(capture.Expression)
+		if err != nil {
+			return nil, err
+		}
+		match := re.FindStringSubmatch(response.Body)
+		if match == nil {
+			return nil, NewNotFoundError("match of " + capture.Expression)
+		}
+		// the first group is the value when the regex has groups
+		if len(match) > 1 {
+			return types.String(match[1]), nil
+		}
+		return types.String(match[0]), nil
+	case types.CaptureJSON:
+		value, err := jsonpath.Get([]byte(response.Body), capture.Expression)
+		if err != nil {
+			return nil, err
+		}
+		return toVariable(value)
+	}
+
+	return nil, NewNotFoundError("source " + capture.Source)
+}
+
+// toVariable converts a json value to the type of a variable. Objects, arrays and null are kept as json.
+func toVariable(value interface{}) (interface{}, error) {
+	switch val := value.(type) {
+	case string:
+		return types.String(val), nil
+	case bool:
+		return types.Bool(val), nil
+	case json.Number:
+		if integer, err := val.Int64(); err == nil {
+			return types.Int(integer), nil
+		}
+		float, err := val.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return types.Float(float), nil
+	}
+
+	content, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.String(content), nil
+}
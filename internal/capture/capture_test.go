@@ -0,0 +1,97 @@
+package capture_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jibaru/do/internal/capture"
+	"github.com/jibaru/do/internal/types"
+)
+
+func TestCapturer_Capture(t *testing.T) {
+	response := types.Response{
+		StatusCode: 201,
+		Body:       `{"data": {"token": "abc", "id": 12, "price": 9.5, "admin": false, "roles": ["read"]}, "ref": "order-345"}`,
+		Headers:    map[string]interface{}{"X-Request-Id": []string{"req-1"}},
+		Cookies: []types.Cookie{
+			{Name: "sid", Value: "old"},
+			{Name: "sid", Value: "new"},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		captures      []types.Capture
+		expected      types.Map
+		expectedError error
+	}{
+		{
+			name: "success all sources",
+			captures: []types.Capture{
+				{Name: "token", Source: types.CaptureJSON, Expression: "$.data.token"},
+				{Name: "id", Source: types.CaptureJSON, Expression: "$.data.id"},
+				{Name: "price", Source: types.CaptureJSON, Expression: "$.data.price"},
+				{Name: "admin", Source: types.CaptureJSON, Expression: "$.data.admin"},
+				{Name: "roles", Source: types.CaptureJSON, Expression: "$.data.roles"},
+				{Name: "requestId", Source: types.CaptureHeader, Expression: "x-request-id"},
+				{Name: "session", Source: types.CaptureCookie, Expression: "sid"},
+				{Name: "order", Source: types.CaptureRegex, Expression: `order-(\d+)`},
+				{Name: "ref", Source: types.CaptureRegex, Expression: `order-\d+`},
+				{Name: "code", Source: types.CaptureStatus},
+			},
+			expected: types.Map{
+				"token":     types.String("abc"),
+				"id":        types.Int(12),
+				"price":     types.Float(9.5),
+				"admin":     types.Bool(false),
+				"roles":     types.String(`["read"]`),
+				"requestId": types.String("req-1"),
+				"session":   types.String("new"),
+				"order":     types.String("345"),
+				"ref":       types.String("order-345"),
+				"code":      types.Int(201),
+			},
+		},
+		{
+			name:     "success body",
+			captures: []types.Capture{{Name: "body", Source: types.CaptureBody}},
+			expected: types.Map{"body": types.String(response.Body)},
+		},
+		{
+			name:          "error json path not found",
+			captures:      []types.Capture{{Name: "token", Source: types.CaptureJSON, Expression: "$.token"}},
+			expectedError: errors.New("can not capture token: json path $.token not found"),
+		},
+		{
+			name:          "error header not found",
+			captures:      []types.Capture{{Name: "etag", Source: types.CaptureHeader, Expression: "ETag"}},
+			expectedError: errors.New("can not capture etag: header ETag not found"),
+		},
+		{
+			name:          "error regex without match",
+			captures:      []types.Capture{{Name: "user", Source: types.CaptureRegex, Expression: `user-\d+`}},
+			expectedError: errors.New(`can not capture user: match of user-\d+ not found`),
+		},
+	}
+
+	c := capture.New()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := c.Capture(tc.captures, response)
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(values, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, values)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package capture
+
+type CanNotCaptureError struct {
+	name   string
+	reason string
+}
+
+type NotFoundError struct {
+	subject string
+}
+
+func NewCanNotCaptureError(name, reason string) error {
+	return CanNotCaptureError{name, reason}
+}
+
+func NewNotFoundError(subject string) error {
+	return NotFoundError{subject}
+}
+
+func (e CanNotCaptureError) Error() string {
+	return "can not capture " + e.name + ": " + e.reason
+}
+
+func (e NotFoundError) Error() string {
+	return e.subject + " not found"
+}
@@ -0,0 +1,11 @@
+package capture
+
+import "github.com/jibaru/do/internal/types"
+
+type Mock struct {
+	CaptureFn func(captures []types.Capture, response types.Response) (types.Map, error)
+}
+
+func (m *Mock) Capture(captures []types.Capture, response types.Response) (types.Map, error) {
+	return m.CaptureFn(captures, response)
+}
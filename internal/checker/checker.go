@@ -0,0 +1,307 @@
+package checker
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jibaru/do/internal/parser"
+	"github.com/jibaru/do/internal/parser/cleaner"
+	"github.com/jibaru/do/internal/parser/extractor"
+	"github.com/jibaru/do/internal/reader"
+	"github.com/jibaru/do/internal/types"
+)
+
+var (
+	funcCallRegexp = regexp.MustCompile(`^([A-Za-z_]\w*)\(`)
+	urlParamRegexp = regexp.MustCompile(`:([A-Za-z_]\w*)`)
+)
+
+// Problem defines an issue found while checking a .do file
+type Problem struct {
+	Filename string        `json:"filename"`
+	Section  types.Section `json:"section,omitempty"`
+	Subject  string        `json:"subject"`
+	Message  string        `json:"message"`
+}
+
+// String returns the problem in the filename: message format
+func (p Problem) String() string {
+	return p.Filename + ": " + p.Message
+}
+
+type Checker interface {
+	// Check validates the .do file without executing the request.
+	Check(filename string) []Problem
+	// CheckContent validates the content of a .do file without executing the request.
+	CheckContent(filename string, content types.FileReaderContent) []Problem
+}
+
+type checker struct {
+	doFileReader     reader.FileReader
+	commentCleaner   cleaner.Cleaner
+	sectionExtractor extractor.Extractor
+	doParser         parser.Parser
+}
+
+func New(
+	doFileReader reader.FileReader,
+	commentCleaner cleaner.Cleaner,
+	sectionExtractor extractor.Extractor,
+	doParser parser.Parser,
+) Checker {
+	return &checker{
+		doFileReader,
+		commentCleaner,
+		sectionExtractor,
+		doParser,
+	}
+}
+
+func (c *checker) Check(filename string) []Problem {
+	content, err := c.doFileReader.Read(filename)
+	if err != nil {
+		return []Problem{{Filename: filename, Message: err.Error()}}
+	}
+
+	return c.CheckContent(filename, content)
+}
+
+func (c *checker) CheckContent(filename string, content types.FileReaderContent) []Problem {
+	problems := make([]Problem, 0)
+	report := func(section types.Section, subject, message string) {
+		problems = append(problems, Problem{Filename: filename, Section: section, Subject: subject, Message: message})
+	}
+
+	cleanedContent, err := c.commentCleaner.Clean(content)
+	if err != nil {
+		report("", "", err.Error())
+		return problems
+	}
+
+	for _, name := range unknownFunctions(cleanedContent) {
+		report("", name, "unknown function "+name)
+	}
+
+	letSentences, err := c.sectionExtractor.Extract(types.LetSection, cleanedContent)
+	if err != nil && !errors.Is(err, extractor.ErrSectionExtractorNoBlock) {
+		report("", "", err.Error())
+		return problems
+	}
+
+	doSentences, err := c.sectionExtractor.Extract(types.DoSection, cleanedContent)
+	if err != nil {
+		report("", "", err.Error())
+		return problems
+	}
+
+	optionSentences, err := c.sectionExtractor.Extract(types.OptionsSection, cleanedContent)
+	if err != nil && !errors.Is(err, extractor.ErrSectionExtractorNoBlock) {
+		report("", "", err.Error())
+		return problems
+	}
+
+	expectSentences, err := c.sectionExtractor.Extract(types.ExpectSection, cleanedContent)
+	if err != nil && !errors.Is(err, extractor.ErrSectionExtractorNoBlock) {
+		report("", "", err.Error())
+		return problems
+	}
+
+	doFile, err := c.doParser.ParseFromContent(content)
+	if err != nil {
+		report("", "", err.Error())
+	}
+
+	for _, name := range unusedVariables(letSentences, doSentences, optionSentences, expectSentences) {
+		report(types.LetSection, name, "variable "+name+" is declared but not used")
+	}
+
+	if doFile == nil {
+		return problems
+	}
+
+	placeholders := urlPlaceholders(string(doFile.Do.URL))
+	for _, placeholder := range placeholders {
+		if _, ok := doFile.Do.Params[placeholder]; !ok {
+			report(types.DoSection, ":"+placeholder, "url placeholder :"+placeholder+" has no value in params")
+		}
+	}
+
+	for _, key := range sortedKeys(doFile.Do.Params) {
+		if !contains(placeholders, key) {
+			report(types.DoSection, key, "param "+key+" is not present in the url")
+		}
+	}
+
+	for _, key := range sortedKeys(doFile.Do.Headers) {
+		if !isValidHeaderName(key) {
+			report(types.DoSection, key, fmt.Sprintf("invalid header name %q", key))
+		}
+	}
+
+	return problems
+}
+
+// unknownFunctions returns the names of the called functions that are not available,
+// ignoring the content inside strings
+func unknownFunctions(content types.CleanedContent) []string {
+	names := make([]string, 0)
+	inQuotes := false
+	inBackticks := false
+
+	text := string(content)
+	for i, ch := range text {
+		if ch == '"' && !inBackticks {
+			inQuotes = !inQuotes
+		}
+		if ch == '`' && !inQuotes {
+			inBackticks = !inBackticks
+		}
+		if inQuotes || inBackticks {
+			continue
+		}
+		if i > 0 && isIdentifierChar(text[i-1]) {
+			continue
+		}
+
+		matches := funcCallRegexp.FindStringSubmatch(text[i:])
+		if matches == nil {
+			continue
+		}
+
+		if !types.IsFuncName(matches[1]) && !contains(names, matches[1]) {
+			names = append(names, matches[1])
+		}
+	}
+
+	return names
+}
+
+// unusedVariables returns the let variables that are not referenced by other
+// variables nor the other sections
+func unusedVariables(letSentences *types.Sentences, usedIn ...*types.Sentences) []string {
+	if letSentences == nil {
+		return nil
+	}
+
+	names := make([]string, 0)
+	for _, sentence := range letSentences.Entries() {
+		used := false
+
+		for _, other := range letSentences.Entries() {
+			if other.Key != sentence.Key && references(other.Value, sentence.Key) {
+				used = true
+				break
+			}
+		}
+
+		for _, sentences := range usedIn {
+			if used || sentences == nil {
+				continue
+			}
+
+			for _, other := range sentences.Entries() {
+				if references(other.Value, sentence.Key) {
+					used = true
+					break
+				}
+			}
+		}
+
+		if !used {
+			names = append(names, sentence.Key)
+		}
+	}
+
+	return names
+}
+
+// references returns true if value uses the variable name
+func references(value interface{}, name string) bool {
+	switch val := value.(type) {
+	case types.ReferenceToVariable:
+		return val.Value == name
+	case types.String:
+		return strings.Contains(string(val), "$"+name)
+	case types.Map:
+		for _, v := range val {
+			if references(v, name) {
+				return true
+			}
+		}
+	case types.Func:
+		for _, arg := range val.Args {
+			if references(arg, name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// urlPlaceholders returns the names of the :params placeholders in the url path
+func urlPlaceholders(url string) []string {
+	path := url
+	if idx := strings.Index(path, "://"); idx != -1 {
+		path = path[idx+3:]
+		slashIdx := strings.Index(path, "/")
+		if slashIdx == -1 {
+			return nil
+		}
+		path = path[slashIdx:]
+	}
+
+	names := make([]string, 0)
+	for _, matches := range urlParamRegexp.FindAllStringSubmatch(path, -1) {
+		if !contains(names, matches[1]) {
+			names = append(names, matches[1])
+		}
+	}
+
+	return names
+}
+
+// isValidHeaderName returns true if name is a valid token as defined in RFC 7230
+func isValidHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, ch := range name {
+		if ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' {
+			continue
+		}
+		if !strings.ContainsRune("!#$%&'*+-.^_`|~", ch) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isIdentifierChar(ch byte) bool {
+	return ch == '_' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9'
+}
+
+func sortedKeys(m types.Map) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
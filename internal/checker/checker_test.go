@@ -0,0 +1,127 @@
+package checker_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jibaru/do/internal/checker"
+	"github.com/jibaru/do/internal/parser"
+	"github.com/jibaru/do/internal/parser/analyzer"
+	"github.com/jibaru/do/internal/parser/caller"
+	"github.com/jibaru/do/internal/parser/cleaner"
+	"github.com/jibaru/do/internal/parser/extractor"
+	"github.com/jibaru/do/internal/parser/normalizer"
+	"github.com/jibaru/do/internal/parser/partitioner"
+	"github.com/jibaru/do/internal/parser/replacer"
+	"github.com/jibaru/do/internal/parser/resolver"
+	"github.com/jibaru/do/internal/parser/taker"
+	"github.com/jibaru/do/internal/reader"
+	"github.com/jibaru/do/internal/types"
+	"github.com/jibaru/do/internal/utils"
+)
+
+func TestChecker_Check(t *testing.T) {
+	testCases := []struct {
+		name     string
+		filename string
+		expected []checker.Problem
+	}{
+		{
+			name:     "valid file",
+			filename: "testdata/01_valid.do",
+			expected: []checker.Problem{},
+		},
+		{
+			name:     "semantic problems",
+			filename: "testdata/02_semantic_problems.do",
+			expected: []checker.Problem{
+				{
+					Filename: "testdata/02_semantic_problems.do",
+					Section:  types.LetSection,
+					Subject:  "unused",
+					Message:  "variable unused is declared but not used",
+				},
+				{
+					Filename: "testdata/02_semantic_problems.do",
+					Section:  types.DoSection,
+					Subject:  ":userId",
+					Message:  "url placeholder :userId has no value in params",
+				},
+				{
+					Filename: "testdata/02_semantic_problems.do",
+					Section:  types.DoSection,
+					Subject:  "id",
+					Message:  "param id is not present in the url",
+				},
+				{
+					Filename: "testdata/02_semantic_problems.do",
+					Section:  types.DoSection,
+					Subject:  "Bad Header",
+					Message:  `invalid header name "Bad Header"`,
+				},
+			},
+		},
+		{
+			name:     "unknown function",
+			filename: "testdata/03_unknown_function.do",
+			expected: []checker.Problem{
+				{
+					Filename: "testdata/03_unknown_function.do",
+					Subject:  "secret",
+					Message:  "unknown function secret",
+				},
+				{
+					Filename: "testdata/03_unknown_function.do",
+					Message:  `invalid value secret("API_TOKEN")`,
+				},
+			},
+		},
+		{
+			name:     "missing url",
+			filename: "testdata/04_missing_url.do",
+			expected: []checker.Problem{
+				{
+					Filename: "testdata/04_missing_url.do",
+					Message:  "url is required",
+				},
+			},
+		},
+		{
+			name:     "file not found",
+			filename: "testdata/not_found.do",
+			expected: []checker.Problem{
+				{
+					Filename: "testdata/not_found.do",
+					Message:  "can not read file testdata/not_found.do",
+				},
+			},
+		},
+	}
+
+	uuidFactory := utils.NewRandomUuidFactory()
+	dateFactory := utils.NewNowDateFactory()
+
+	doFileReader := reader.NewFileReader()
+	commentCleaner := cleaner.New()
+	sectionExtractor := extractor.New(taker.New(), normalizer.New(), partitioner.New(), analyzer.New())
+	theParser := parser.New(
+		doFileReader,
+		commentCleaner,
+		sectionExtractor,
+		replacer.New(),
+		caller.New(uuidFactory, dateFactory, nil),
+		resolver.NewLetResolver(uuidFactory, dateFactory, nil),
+	)
+
+	c := checker.New(doFileReader, commentCleaner, sectionExtractor, theParser)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			problems := c.Check(tc.filename)
+
+			if !reflect.DeepEqual(problems, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, problems)
+			}
+		})
+	}
+}
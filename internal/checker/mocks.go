@@ -0,0 +1,16 @@
+package checker
+
+import "github.com/jibaru/do/internal/types"
+
+type Mock struct {
+	CheckFn        func(filename string) []Problem
+	CheckContentFn func(filename string, content types.FileReaderContent) []Problem
+}
+
+func (m *Mock) Check(filename string) []Problem {
+	return m.CheckFn(filename)
+}
+
+func (m *Mock) CheckContent(filename string, content types.FileReaderContent) []Problem {
+	return m.CheckContentFn(filename, content)
+}
@@ -0,0 +1,26 @@
+package cookies
+
+type InvalidJarError struct {
+	path string
+	err  error
+}
+
+type InvalidSessionNameError struct {
+	name string
+}
+
+func NewInvalidJarError(path string, err error) error {
+	return InvalidJarError{path, err}
+}
+
+func NewInvalidSessionNameError(name string) error {
+	return InvalidSessionNameError{name}
+}
+
+func (e InvalidJarError) Error() string {
+	return "invalid cookie jar " + e.path + ": " + e.err.Error()
+}
+
+func (e InvalidSessionNameError) Error() string {
+	return "invalid session name " + e.name + ": use letters, digits, '-' and '_'"
+}
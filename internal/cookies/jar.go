@@ -0,0 +1,256 @@
+package cookies
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+// SessionsDir is the directory, relative to the working directory, where named sessions are kept
+const SessionsDir = ".do/sessions"
+
+type Jar interface {
+	http.CookieJar
+	// All returns the cookies that are not expired, sorted by domain, path and name.
+	All() []types.Cookie
+	// Clear removes every cookie.
+	Clear()
+	// Save writes the cookies to the file of the jar, creating its directory.
+	Save() error
+}
+
+type jar struct {
+	mu      sync.Mutex
+	path    string
+	cookies []types.Cookie
+	now     func() time.Time
+}
+
+// New returns a jar persisted in the file at path, loading its cookies when it exists
+func New(path string) (Jar, error) {
+	j := &jar{path: path, cookies: make([]types.Cookie, 0), now: time.Now}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return j, nil
+	} else if err != nil {
+		return nil, NewInvalidJarError(path, err)
+	}
+
+	if err = json.Unmarshal(content, &j.cookies); err != nil {
+		return nil, NewInvalidJarError(path, err)
+	}
+
+	return j, nil
+}
+
+// SessionPath returns the path of the jar of a named session
+func SessionPath(name string) (string, error) {
+	if name == "" || strings.Trim(name, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_") != "" {
+		return "", NewInvalidSessionNameError(name)
+	}
+
+	return filepath.Join(SessionsDir, name+".json"), nil
+}
+
+// FromHTTP returns the cookie set by a response, without the defaults of the jar
+func FromHTTP(c *http.Cookie) types.Cookie {
+	cookie := types.Cookie{
+		Name:     c.Name,
+		Value:    c.Value,
+		Domain:   c.Domain,
+		Path:     c.Path,
+		MaxAge:   c.MaxAge,
+		Secure:   c.Secure,
+		HttpOnly: c.HttpOnly,
+		SameSite: sameSite(c.SameSite),
+	}
+
+	if !c.Expires.IsZero() {
+		expires := c.Expires.UTC()
+		cookie.Expires = &expires
+	}
+
+	return cookie
+}
+
+func (j *jar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := j.now()
+	host := strings.ToLower(u.Hostname())
+
+	for _, c := range cookies {
+		cookie := FromHTTP(c)
+		cookie.Domain = strings.TrimPrefix(strings.ToLower(cookie.Domain), ".")
+		if cookie.Domain == "" {
+			cookie.Domain, cookie.HostOnly = host, true
+		} else if !domainMatch(host, cookie.Domain) {
+			continue
+		}
+
+		if !strings.HasPrefix(cookie.Path, "/") {
+			cookie.Path = defaultPath(u.Path)
+		}
+
+		if c.MaxAge > 0 {
+			expires := now.Add(time.Duration(c.MaxAge) * time.Second).UTC()
+			cookie.Expires = &expires
+		}
+		// the jar keeps the moment the cookie expires instead of its age
+		cookie.MaxAge = 0
+
+		j.remove(cookie)
+		if c.MaxAge < 0 || (cookie.Expires != nil && !cookie.Expires.After(now)) {
+			continue
+		}
+		j.cookies = append(j.cookies, cookie)
+	}
+}
+
+func (j *jar) Cookies(u *url.URL) []*http.Cookie {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := j.now()
+	host := strings.ToLower(u.Hostname())
+	requestPath := u.Path
+	if requestPath == "" {
+		requestPath = "/"
+	}
+
+	matched := make([]types.Cookie, 0)
+	for _, cookie := range j.cookies {
+		if cookie.Expires != nil && !cookie.Expires.After(now) {
+			continue
+		}
+		if cookie.HostOnly && host != cookie.Domain || !cookie.HostOnly && !domainMatch(host, cookie.Domain) {
+			continue
+		}
+		if !pathMatch(requestPath, cookie.Path) || cookie.Secure && u.Scheme != "https" {
+			continue
+		}
+		matched = append(matched, cookie)
+	}
+
+	// the cookies with longer paths are sent first
+	sort.SliceStable(matched, func(a, b int) bool {
+		return len(matched[a].Path) > len(matched[b].Path)
+	})
+
+	result := make([]*http.Cookie, 0, len(matched))
+	for _, cookie := range matched {
+		result = append(result, &http.Cookie{Name: cookie.Name, Value: cookie.Value})
+	}
+
+	return result
+}
+
+func (j *jar) All() []types.Cookie {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := j.now()
+	result := make([]types.Cookie, 0, len(j.cookies))
+	for _, cookie := range j.cookies {
+		if cookie.Expires == nil || cookie.Expires.After(now) {
+			result = append(result, cookie)
+		}
+	}
+
+	sort.Slice(result, func(a, b int) bool {
+		if result[a].Domain != result[b].Domain {
+			return result[a].Domain < result[b].Domain
+		}
+		if result[a].Path != result[b].Path {
+			return result[a].Path < result[b].Path
+		}
+		return result[a].Name < result[b].Name
+	})
+
+	return result
+}
+
+func (j *jar) Clear() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.cookies = make([]types.Cookie, 0)
+}
+
+func (j *jar) Save() error {
+	content, err := json.MarshalIndent(j.All(), "", "  ")
+	if err != nil {
+		return NewInvalidJarError(j.path, err)
+	}
+
+	if dir := filepath.Dir(j.path); dir != "." {
+		if err = os.MkdirAll(dir, 0755); err != nil {
+			return NewInvalidJarError(j.path, err)
+		}
+	}
+
+	if err = os.WriteFile(j.path, append(content, '\n'), 0600); err != nil {
+		return NewInvalidJarError(j.path, err)
+	}
+
+	return nil
+}
+
+// remove deletes the cookie with the same name, domain and path
+func (j *jar) remove(cookie types.Cookie) {
+	for i, other := range j.cookies {
+		if other.Name == cookie.Name && other.Domain == cookie.Domain && other.Path == cookie.Path {
+			j.cookies = append(j.cookies[:i], j.cookies[i+1:]...)
+			return
+		}
+	}
+}
+
+// domainMatch returns true when the host is the domain or one of its subdomains
+func domainMatch(host, domain string) bool {
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// pathMatch returns true when the request path is the cookie path or is inside it
+func pathMatch(requestPath, cookiePath string) bool {
+	if requestPath == cookiePath {
+		return true
+	}
+
+	return strings.HasPrefix(requestPath, cookiePath) &&
+		(strings.HasSuffix(cookiePath, "/") || requestPath[len(cookiePath)] == '/')
+}
+
+// defaultPath returns the directory of the request path, used when the cookie does not define one
+func defaultPath(requestPath string) string {
+	if !strings.HasPrefix(requestPath, "/") || strings.Count(requestPath, "/") == 1 {
+		return "/"
+	}
+
+	return path.Dir(requestPath)
+}
+
+func sameSite(mode http.SameSite) string {
+	switch mode {
+	case http.SameSiteLaxMode:
+		return "Lax"
+	case http.SameSiteStrictMode:
+		return "Strict"
+	case http.SameSiteNoneMode:
+		return "None"
+	}
+
+	return ""
+}
@@ -0,0 +1,210 @@
+package cookies_test
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jibaru/do/internal/cookies"
+)
+
+func TestJar_Cookies(t *testing.T) {
+	testCases := []struct {
+		name     string
+		setURL   string
+		set      []*http.Cookie
+		url      string
+		expected []string
+	}{
+		{
+			name:     "success host only cookie",
+			setURL:   "https://api.example.com/login",
+			set:      []*http.Cookie{{Name: "session", Value: "abc"}},
+			url:      "https://api.example.com/users",
+			expected: []string{"session=abc"},
+		},
+		{
+			name:     "success host only cookie not sent to subdomains",
+			setURL:   "https://example.com/login",
+			set:      []*http.Cookie{{Name: "session", Value: "abc"}},
+			url:      "https://api.example.com/users",
+			expected: []string{},
+		},
+		{
+			name:     "success domain cookie sent to subdomains",
+			setURL:   "https://example.com/login",
+			set:      []*http.Cookie{{Name: "session", Value: "abc", Domain: ".example.com"}},
+			url:      "https://api.example.com/users",
+			expected: []string{"session=abc"},
+		},
+		{
+			name:     "success cookie of other domain ignored",
+			setURL:   "https://example.com/login",
+			set:      []*http.Cookie{{Name: "session", Value: "abc", Domain: "other.com"}},
+			url:      "https://other.com/users",
+			expected: []string{},
+		},
+		{
+			name:   "success path match sorted by path",
+			setURL: "https://example.com/api/login",
+			set: []*http.Cookie{
+				{Name: "root", Value: "1", Path: "/"},
+				{Name: "api", Value: "2"},
+				{Name: "admin", Value: "3", Path: "/admin"},
+			},
+			url:      "https://example.com/api/users",
+			expected: []string{"api=2", "root=1"},
+		},
+		{
+			name:     "success secure cookie not sent over http",
+			setURL:   "https://example.com/login",
+			set:      []*http.Cookie{{Name: "session", Value: "abc", Secure: true}},
+			url:      "http://example.com/users",
+			expected: []string{},
+		},
+		{
+			name:   "success expired cookie removed",
+			setURL: "https://example.com/login",
+			set: []*http.Cookie{
+				{Name: "session", Value: "abc"},
+				{Name: "session", Value: "", MaxAge: -1},
+			},
+			url:      "https://example.com/users",
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jar, err := cookies.New(filepath.Join(t.TempDir(), "jar.json"))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			setURL, _ := url.Parse(tc.setURL)
+			jar.SetCookies(setURL, tc.set)
+
+			u, _ := url.Parse(tc.url)
+			result := make([]string, 0)
+			for _, cookie := range jar.Cookies(u) {
+				result = append(result, cookie.String())
+			}
+
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestJar_Save(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions", "jar.json")
+	u, _ := url.Parse("https://example.com/login")
+	expires := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	jar, err := cookies.New(path)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	jar.SetCookies(u, []*http.Cookie{
+		{Name: "session", Value: "abc", HttpOnly: true},
+		{Name: "theme", Value: "dark", Expires: expires},
+		{Name: "old", Value: "1", Expires: time.Now().Add(-time.Hour)},
+	})
+
+	if err = jar.Save(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	loaded, err := cookies.New(path)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !reflect.DeepEqual(loaded.All(), jar.All()) || len(loaded.All()) != 2 {
+		t.Errorf("expected %+v, got %+v", jar.All(), loaded.All())
+	}
+
+	loaded.Clear()
+	if len(loaded.All()) != 0 {
+		t.Errorf("expected no cookies, got %+v", loaded.All())
+	}
+}
+
+func TestNew(t *testing.T) {
+	invalid := filepath.Join(t.TempDir(), "invalid.json")
+	_ = os.WriteFile(invalid, []byte("{"), 0644)
+
+	testCases := []struct {
+		name          string
+		path          string
+		expectedError error
+	}{
+		{
+			name: "success file does not exist",
+			path: filepath.Join(t.TempDir(), "new.json"),
+		},
+		{
+			name:          "error invalid file",
+			path:          invalid,
+			expectedError: cookies.NewInvalidJarError(invalid, errors.New("unexpected end of JSON input")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := cookies.New(tc.path)
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestSessionPath(t *testing.T) {
+	testCases := []struct {
+		name          string
+		session       string
+		expected      string
+		expectedError error
+	}{
+		{
+			name:     "success",
+			session:  "staging_admin-1",
+			expected: filepath.Join(".do", "sessions", "staging_admin-1.json"),
+		},
+		{
+			name:          "error path in name",
+			session:       "../secrets",
+			expectedError: cookies.NewInvalidSessionNameError("../secrets"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path, err := cookies.SessionPath(tc.session)
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if path != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, path)
+			}
+		})
+	}
+}
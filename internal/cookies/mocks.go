@@ -0,0 +1,36 @@
+package cookies
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+type Mock struct {
+	SetCookiesFn func(u *url.URL, cookies []*http.Cookie)
+	CookiesFn    func(u *url.URL) []*http.Cookie
+	AllFn        func() []types.Cookie
+	ClearFn      func()
+	SaveFn       func() error
+}
+
+func (m *Mock) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	m.SetCookiesFn(u, cookies)
+}
+
+func (m *Mock) Cookies(u *url.URL) []*http.Cookie {
+	return m.CookiesFn(u)
+}
+
+func (m *Mock) All() []types.Cookie {
+	return m.AllFn()
+}
+
+func (m *Mock) Clear() {
+	m.ClearFn()
+}
+
+func (m *Mock) Save() error {
+	return m.SaveFn()
+}
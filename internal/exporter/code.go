@@ -0,0 +1,82 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jibaru/do/internal/request"
+	"github.com/jibaru/do/internal/types"
+)
+
+// snippet defines the values of a request used to write code that sends it
+type snippet struct {
+	method  string
+	url     string
+	query   []header
+	headers []header
+	body    *string
+	fields  []part
+	files   []part
+}
+
+// newSnippet returns the values of the request, with the url without the query of the do file
+// so the code can encode it
+func newSnippet(doFile types.DoFile) (*snippet, error) {
+	withoutQuery := doFile
+	withoutQuery.Do.Query = nil
+
+	url, err := request.URL(withoutQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	// digest and oauth2 need round trips and sigv4 a signer that the snippets do not implement
+	if err := checkAuth(doFile, types.AuthDigest, types.AuthOAuth2, types.AuthSigV4); err != nil {
+		return nil, err
+	}
+
+	s := &snippet{method: string(doFile.Do.Method), url: url, headers: headers(doFile)}
+
+	for _, key := range sortedKeys(doFile.Do.Query) {
+		s.query = append(s.query, header{Key: key, Value: fmt.Sprintf("%v", doFile.Do.Query[key])})
+	}
+
+	if body, ok := stringBody(doFile); ok {
+		s.body = &body
+	}
+
+	for _, p := range parts(doFile) {
+		if p.IsFile {
+			s.files = append(s.files, p)
+		} else {
+			s.fields = append(s.fields, p)
+		}
+	}
+
+	return s, nil
+}
+
+func (s *snippet) isMultipart() bool {
+	return len(s.fields) > 0 || len(s.files) > 0
+}
+
+// querySeparator returns the separator to append a query to the url, & when it already has one
+func querySeparator(url string) string {
+	if strings.Contains(url, "?") {
+		return "&"
+	}
+
+	return "?"
+}
+
+// jsonQuote returns the value as a double quoted string literal, valid in JSON, JavaScript, Python and Java
+func jsonQuote(value string) string {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(value)
+
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
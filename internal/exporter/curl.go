@@ -0,0 +1,72 @@
+package exporter
+
+import (
+	"strings"
+
+	"github.com/jibaru/do/internal/request"
+	"github.com/jibaru/do/internal/types"
+)
+
+type curlExporter struct{}
+
+// NewCurl returns an exporter that renders the request as a curl command
+func NewCurl() Exporter {
+	return &curlExporter{}
+}
+
+func (e *curlExporter) Export(doFile types.DoFile) (string, error) {
+	if err := checkAuth(doFile, types.AuthOAuth2); err != nil {
+		return "", err
+	}
+
+	url, err := request.URL(doFile)
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{"curl"}
+	method := string(doFile.Do.Method)
+	if method != "GET" || doFile.Do.Body != nil {
+		args[0] += " -X " + shellQuote(method)
+	}
+	args[0] += " " + shellQuote(url)
+
+	if auth, ok := digestAuth(doFile); ok {
+		args = append(args, "--digest -u "+shellQuote(auth.Username+":"+auth.Password))
+	}
+
+	if auth := doFile.Do.Auth; auth != nil && auth.Type == types.AuthSigV4 {
+		args = append(args, "--aws-sigv4 "+shellQuote("aws:amz:"+auth.Region+":"+auth.Service),
+			"-u "+shellQuote(auth.AccessKey+":"+auth.SecretKey))
+		if auth.SessionToken != "" {
+			args = append(args, "-H "+shellQuote("X-Amz-Security-Token: "+auth.SessionToken))
+		}
+	}
+
+	for _, h := range headers(doFile) {
+		args = append(args, "-H "+shellQuote(h.Key+": "+h.Value))
+	}
+
+	if body, ok := stringBody(doFile); ok {
+		args = append(args, "--data-raw "+shellQuote(body))
+	}
+
+	for _, p := range parts(doFile) {
+		if p.IsFile {
+			args = append(args, "-F "+shellQuote(p.Key+"=@"+p.Value))
+		} else {
+			args = append(args, "--form-string "+shellQuote(p.Key+"="+p.Value))
+		}
+	}
+
+	return strings.Join(args, " \\\n  "), nil
+}
+
+// shellQuote wraps the value in single quotes so a POSIX shell does not expand it
+func shellQuote(value string) string {
+	if value != "" && strings.Trim(value, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:") == "" {
+		return value
+	}
+
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
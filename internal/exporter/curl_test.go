@@ -0,0 +1,174 @@
+package exporter_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jibaru/do/internal/exporter"
+	"github.com/jibaru/do/internal/types"
+)
+
+func TestCurlExporter_Export(t *testing.T) {
+	testCases := []struct {
+		name          string
+		doFile        types.DoFile
+		expected      string
+		expectedError error
+	}{
+		{
+			name: "success get",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method: "GET",
+					URL:    "https://api.example.com/users/:id",
+					Params: types.Map{"id": types.Int(12)},
+					Query:  types.Map{"q": types.String("john's & co"), "page": types.Int(2)},
+				},
+			},
+			expected: "curl 'https://api.example.com/users/12?page=2&q=john%27s+%26+co'",
+		},
+		{
+			name: "success string body",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method: "POST",
+					URL:    "https://api.example.com/users",
+					Headers: types.Map{
+						"Content-Type":  types.String("application/json"),
+						"Authorization": types.String("Bearer token"),
+					},
+					Body: types.String(`{"name": "John's"}`),
+				},
+			},
+			expected: "curl -X POST https://api.example.com/users \\\n" +
+				"  -H 'Authorization: Bearer token' \\\n" +
+				"  -H 'Content-Type: application/json' \\\n" +
+				"  --data-raw '{\"name\": \"John'\\''s\"}'",
+		},
+		{
+			name: "success multipart body",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method: "POST",
+					URL:    "https://api.example.com/upload",
+					Headers: types.Map{
+						"Content-Type": types.String("multipart/form-data"),
+					},
+					Body: types.Map{
+						"name":   types.String("@not a file"),
+						"avatar": types.File{Path: "/tmp/my avatar.png"},
+					},
+				},
+			},
+			expected: "curl -X POST https://api.example.com/upload \\\n" +
+				"  -F 'avatar=@/tmp/my avatar.png' \\\n" +
+				"  --form-string 'name=@not a file'",
+		},
+		{
+			name: "success multipart body skips the values that are not strings or files",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method: "POST",
+					URL:    "https://api.example.com/upload",
+					Headers: types.Map{
+						"Content-Type": types.String("multipart/form-data"),
+					},
+					Body: types.Map{
+						"name": types.String("john"),
+						"age":  types.Int(30),
+					},
+				},
+			},
+			expected: "curl -X POST https://api.example.com/upload \\\n" +
+				"  --form-string 'name=john'",
+		},
+		{
+			name: "success basic auth replaces the authorization header",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method:  "GET",
+					URL:     "https://api.example.com/me",
+					Headers: types.Map{"authorization": types.String("Bearer old")},
+					Auth:    &types.Auth{Type: types.AuthBasic, Username: "admin", Password: "secret"},
+				},
+			},
+			expected: "curl https://api.example.com/me \\\n" +
+				"  -H 'Authorization: Basic YWRtaW46c2VjcmV0'",
+		},
+		{
+			name: "success digest auth",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method: "GET",
+					URL:    "https://api.example.com/me",
+					Auth:   &types.Auth{Type: types.AuthDigest, Username: "admin", Password: "it's"},
+				},
+			},
+			expected: "curl https://api.example.com/me \\\n" +
+				"  --digest -u 'admin:it'\\''s'",
+		},
+		{
+			name: "success sigv4 auth",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method: "GET",
+					URL:    "https://bucket.s3.amazonaws.com/file.txt",
+					Auth: &types.Auth{
+						Type:         types.AuthSigV4,
+						AccessKey:    "AKID",
+						SecretKey:    "secret",
+						SessionToken: "session",
+						Region:       "us-east-1",
+						Service:      "s3",
+					},
+				},
+			},
+			expected: "curl https://bucket.s3.amazonaws.com/file.txt \\\n" +
+				"  --aws-sigv4 aws:amz:us-east-1:s3 \\\n" +
+				"  -u AKID:secret \\\n" +
+				"  -H 'X-Amz-Security-Token: session'",
+		},
+		{
+			name: "error oauth2 auth",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method: "GET",
+					URL:    "https://api.example.com/me",
+					Auth:   &types.Auth{Type: types.AuthOAuth2, TokenURL: "https://auth.example.com/token", ClientID: "app"},
+				},
+			},
+			expectedError: errors.New("oauth2 auth is not supported by this format"),
+		},
+		{
+			name: "error param not found",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method: "GET",
+					URL:    "https://api.example.com/users",
+					Params: types.Map{"id": types.Int(12)},
+				},
+			},
+			expectedError: errors.New("can not replace param: id"),
+		},
+	}
+
+	e := exporter.NewCurl()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			command, err := e.Export(tc.doFile)
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if command != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, command)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package exporter
+
+type UnsupportedAuthError struct {
+	authType string
+}
+
+func NewUnsupportedAuthError(authType string) error {
+	return UnsupportedAuthError{authType}
+}
+
+func (e UnsupportedAuthError) Error() string {
+	return e.authType + " auth is not supported by this format"
+}
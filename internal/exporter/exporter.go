@@ -0,0 +1,122 @@
+package exporter
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+type Exporter interface {
+	// Export renders the resolved request of the do file in another format.
+	Export(doFile types.DoFile) (string, error)
+}
+
+// header defines a header of the request as it is sent
+type header struct {
+	Key   string
+	Value string
+}
+
+// part defines a part of a multipart body
+type part struct {
+	Key    string
+	Value  string
+	IsFile bool
+}
+
+// headers returns the headers of the do file sorted by key, including the Authorization header of
+// basic and bearer auth. The Content-Type header is omitted for multipart bodies because the boundary
+// is generated by the client.
+func headers(doFile types.DoFile) []header {
+	_, isMultipart := doFile.Do.Body.(types.Map)
+
+	values := make(types.Map, len(doFile.Do.Headers)+1)
+	for key, value := range doFile.Do.Headers {
+		values[key] = value
+	}
+
+	if auth := doFile.Do.Auth; auth != nil && (auth.Type == types.AuthBasic || auth.Type == types.AuthBearer) {
+		for key := range values {
+			if strings.EqualFold(key, "Authorization") {
+				delete(values, key)
+			}
+		}
+
+		values["Authorization"] = types.String("Bearer " + auth.Token)
+		if auth.Type == types.AuthBasic {
+			values["Authorization"] = types.String("Basic " + base64.StdEncoding.EncodeToString([]byte(auth.Username+":"+auth.Password)))
+		}
+	}
+
+	result := make([]header, 0, len(values))
+	for _, key := range sortedKeys(values) {
+		if isMultipart && strings.EqualFold(key, "Content-Type") {
+			continue
+		}
+		result = append(result, header{Key: key, Value: fmt.Sprintf("%v", values[key])})
+	}
+
+	return result
+}
+
+// digestAuth returns the auth of the do file when it is digest, which needs a round trip to the server
+func digestAuth(doFile types.DoFile) (*types.Auth, bool) {
+	auth := doFile.Do.Auth
+	return auth, auth != nil && auth.Type == types.AuthDigest
+}
+
+// checkAuth returns an error when the auth of the do file is one of the unsupported types
+func checkAuth(doFile types.DoFile, unsupported ...string) error {
+	auth := doFile.Do.Auth
+	if auth == nil {
+		return nil
+	}
+
+	for _, authType := range unsupported {
+		if auth.Type == authType {
+			return NewUnsupportedAuthError(authType)
+		}
+	}
+
+	return nil
+}
+
+// parts returns the parts of a multipart body sorted by key, or nil when the body is not a map.
+// Like the request sent by do, only the strings and the files are parts.
+func parts(doFile types.DoFile) []part {
+	body, ok := doFile.Do.Body.(types.Map)
+	if !ok {
+		return nil
+	}
+
+	result := make([]part, 0, len(body))
+	for _, key := range sortedKeys(body) {
+		switch value := body[key].(type) {
+		case types.File:
+			result = append(result, part{Key: key, Value: value.Path, IsFile: true})
+		case types.String:
+			result = append(result, part{Key: key, Value: string(value)})
+		}
+	}
+
+	return result
+}
+
+// stringBody returns the body when it is a string
+func stringBody(doFile types.DoFile) (string, bool) {
+	body, ok := doFile.Do.Body.(types.String)
+	return string(body), ok
+}
+
+func sortedKeys(m types.Map) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,114 @@
+package exporter
+
+import (
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+type goExporter struct{}
+
+// NewGo returns an exporter that renders the request as a Go program using net/http
+func NewGo() Exporter {
+	return &goExporter{}
+}
+
+func (e *goExporter) Export(doFile types.DoFile) (string, error) {
+	s, err := newSnippet(doFile)
+	if err != nil {
+		return "", err
+	}
+
+	imports := map[string]bool{"fmt": true, "io": true, "net/http": true}
+	code := &strings.Builder{}
+
+	url := strconv.Quote(s.url)
+	if len(s.query) > 0 {
+		imports["net/url"] = true
+		code.WriteString("query := url.Values{}\n")
+		for _, q := range s.query {
+			code.WriteString("query.Set(" + strconv.Quote(q.Key) + ", " + strconv.Quote(q.Value) + ")\n")
+		}
+		code.WriteString("\n")
+		url += ` + "` + querySeparator(s.url) + `" + query.Encode()`
+	}
+
+	body := "nil"
+	switch {
+	case s.body != nil:
+		imports["strings"] = true
+		body = "body"
+		code.WriteString("body := strings.NewReader(" + goQuote(*s.body) + ")\n\n")
+	case s.isMultipart():
+		imports["bytes"] = true
+		imports["mime/multipart"] = true
+		body = "body"
+		code.WriteString("body := &bytes.Buffer{}\nwriter := multipart.NewWriter(body)\n")
+		// the parts are slices, not maps, so they are written in the same sorted order on every run
+		if len(s.fields) > 0 {
+			code.WriteString("fields := []struct{ key, value string }{\n")
+			for _, p := range s.fields {
+				code.WriteString("{" + strconv.Quote(p.Key) + ", " + strconv.Quote(p.Value) + "},\n")
+			}
+			code.WriteString("}\nfor _, field := range fields {\nif err := writer.WriteField(field.key, field.value); err != nil {\npanic(err)\n}\n}\n")
+		}
+		if len(s.files) > 0 {
+			imports["os"] = true
+			imports["path/filepath"] = true
+			code.WriteString("files := []struct{ key, path string }{\n")
+			for _, p := range s.files {
+				code.WriteString("{" + strconv.Quote(p.Key) + ", " + strconv.Quote(p.Value) + "},\n")
+			}
+			code.WriteString("}\nfor _, upload := range files {\n" +
+				"file, err := os.Open(upload.path)\nif err != nil {\npanic(err)\n}\n" +
+				"part, err := writer.CreateFormFile(upload.key, filepath.Base(upload.path))\nif err != nil {\npanic(err)\n}\n" +
+				"if _, err = io.Copy(part, file); err != nil {\npanic(err)\n}\nfile.Close()\n}\n")
+		}
+		code.WriteString("if err := writer.Close(); err != nil {\npanic(err)\n}\n\n")
+	}
+
+	code.WriteString("req, err := http.NewRequest(" + strconv.Quote(s.method) + ", " + url + ", " + body + ")\n")
+	code.WriteString("if err != nil {\npanic(err)\n}\n")
+	for _, h := range s.headers {
+		if strings.EqualFold(h.Key, "Host") {
+			// net/http sends the Host of the request instead of the header
+			code.WriteString("req.Host = " + strconv.Quote(h.Value) + "\n")
+			continue
+		}
+		code.WriteString("req.Header.Set(" + strconv.Quote(h.Key) + ", " + strconv.Quote(h.Value) + ")\n")
+	}
+	if s.isMultipart() {
+		code.WriteString("req.Header.Set(\"Content-Type\", writer.FormDataContentType())\n")
+	}
+
+	code.WriteString("\nres, err := http.DefaultClient.Do(req)\nif err != nil {\npanic(err)\n}\ndefer res.Body.Close()\n\n" +
+		"resBody, err := io.ReadAll(res.Body)\nif err != nil {\npanic(err)\n}\n\n" +
+		"fmt.Println(res.Status)\nfmt.Println(string(resBody))\n")
+
+	packages := make([]string, 0, len(imports))
+	for name := range imports {
+		packages = append(packages, strconv.Quote(name))
+	}
+	sort.Strings(packages)
+
+	source := "package main\n\nimport (\n" + strings.Join(packages, "\n") + "\n)\n\nfunc main() {\n" + code.String() + "}\n"
+
+	formatted, err := format.Source([]byte(source))
+	if err != nil {
+		return "", err
+	}
+
+	return string(formatted), nil
+}
+
+// goQuote returns the value as a raw string literal when it has double quotes, or as an interpreted one
+func goQuote(value string) string {
+	if strings.Contains(value, `"`) && !strings.Contains(value, "`") && !strings.Contains(value, "\r") {
+		return "`" + value + "`"
+	}
+
+	return strconv.Quote(value)
+}
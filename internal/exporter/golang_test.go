@@ -0,0 +1,259 @@
+package exporter_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jibaru/do/internal/exporter"
+	"github.com/jibaru/do/internal/types"
+)
+
+func TestGoExporter_Export(t *testing.T) {
+	testCases := []struct {
+		name          string
+		doFile        types.DoFile
+		expected      string
+		expectedError error
+	}{
+		{
+			name: "success get with query",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method:  "GET",
+					URL:     "https://api.example.com/users/:id",
+					Params:  types.Map{"id": types.Int(12)},
+					Query:   types.Map{"q": types.String("john's & co"), "page": types.Int(2)},
+					Headers: types.Map{"Accept": types.String("application/json")},
+				},
+			},
+			expected: "package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"fmt\"\n" +
+				"\t\"io\"\n" +
+				"\t\"net/http\"\n" +
+				"\t\"net/url\"\n" +
+				")\n" +
+				"\n" +
+				"func main() {\n" +
+				"\tquery := url.Values{}\n" +
+				"\tquery.Set(\"page\", \"2\")\n" +
+				"\tquery.Set(\"q\", \"john's & co\")\n" +
+				"\n" +
+				"\treq, err := http.NewRequest(\"GET\", \"https://api.example.com/users/12\"+\"?\"+query.Encode(), nil)\n" +
+				"\tif err != nil {\n" +
+				"\t\tpanic(err)\n" +
+				"\t}\n" +
+				"\treq.Header.Set(\"Accept\", \"application/json\")\n" +
+				"\n" +
+				"\tres, err := http.DefaultClient.Do(req)\n" +
+				"\tif err != nil {\n" +
+				"\t\tpanic(err)\n" +
+				"\t}\n" +
+				"\tdefer res.Body.Close()\n" +
+				"\n" +
+				"\tresBody, err := io.ReadAll(res.Body)\n" +
+				"\tif err != nil {\n" +
+				"\t\tpanic(err)\n" +
+				"\t}\n" +
+				"\n" +
+				"\tfmt.Println(res.Status)\n" +
+				"\tfmt.Println(string(resBody))\n" +
+				"}\n",
+		},
+		{
+			name: "success string body",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method:  "POST",
+					URL:     "https://api.example.com/users",
+					Headers: types.Map{"Content-Type": types.String("application/json")},
+					Body:    types.String(`{"name": "John"}`),
+				},
+			},
+			expected: "package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"fmt\"\n" +
+				"\t\"io\"\n" +
+				"\t\"net/http\"\n" +
+				"\t\"strings\"\n" +
+				")\n" +
+				"\n" +
+				"func main() {\n" +
+				"\tbody := strings.NewReader(`{\"name\": \"John\"}`)\n" +
+				"\n" +
+				"\treq, err := http.NewRequest(\"POST\", \"https://api.example.com/users\", body)\n" +
+				"\tif err != nil {\n" +
+				"\t\tpanic(err)\n" +
+				"\t}\n" +
+				"\treq.Header.Set(\"Content-Type\", \"application/json\")\n" +
+				"\n" +
+				"\tres, err := http.DefaultClient.Do(req)\n" +
+				"\tif err != nil {\n" +
+				"\t\tpanic(err)\n" +
+				"\t}\n" +
+				"\tdefer res.Body.Close()\n" +
+				"\n" +
+				"\tresBody, err := io.ReadAll(res.Body)\n" +
+				"\tif err != nil {\n" +
+				"\t\tpanic(err)\n" +
+				"\t}\n" +
+				"\n" +
+				"\tfmt.Println(res.Status)\n" +
+				"\tfmt.Println(string(resBody))\n" +
+				"}\n",
+		},
+		{
+			name: "success multipart body",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method:  "POST",
+					URL:     "https://api.example.com/upload",
+					Headers: types.Map{"Content-Type": types.String("multipart/form-data")},
+					Body: types.Map{
+						"name":   types.String("john"),
+						"avatar": types.File{Path: "/tmp/avatar.png"},
+					},
+				},
+			},
+			expected: "package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"bytes\"\n" +
+				"\t\"fmt\"\n" +
+				"\t\"io\"\n" +
+				"\t\"mime/multipart\"\n" +
+				"\t\"net/http\"\n" +
+				"\t\"os\"\n" +
+				"\t\"path/filepath\"\n" +
+				")\n" +
+				"\n" +
+				"func main() {\n" +
+				"\tbody := &bytes.Buffer{}\n" +
+				"\twriter := multipart.NewWriter(body)\n" +
+				"\tfields := []struct{ key, value string }{\n" +
+				"\t\t{\"name\", \"john\"},\n" +
+				"\t}\n" +
+				"\tfor _, field := range fields {\n" +
+				"\t\tif err := writer.WriteField(field.key, field.value); err != nil {\n" +
+				"\t\t\tpanic(err)\n" +
+				"\t\t}\n" +
+				"\t}\n" +
+				"\tfiles := []struct{ key, path string }{\n" +
+				"\t\t{\"avatar\", \"/tmp/avatar.png\"},\n" +
+				"\t}\n" +
+				"\tfor _, upload := range files {\n" +
+				"\t\tfile, err := os.Open(upload.path)\n" +
+				"\t\tif err != nil {\n" +
+				"\t\t\tpanic(err)\n" +
+				"\t\t}\n" +
+				"\t\tpart, err := writer.CreateFormFile(upload.key, filepath.Base(upload.path))\n" +
+				"\t\tif err != nil {\n" +
+				"\t\t\tpanic(err)\n" +
+				"\t\t}\n" +
+				"\t\tif _, err = io.Copy(part, file); err != nil {\n" +
+				"\t\t\tpanic(err)\n" +
+				"\t\t}\n" +
+				"\t\tfile.Close()\n" +
+				"\t}\n" +
+				"\tif err := writer.Close(); err != nil {\n" +
+				"\t\tpanic(err)\n" +
+				"\t}\n" +
+				"\n" +
+				"\treq, err := http.NewRequest(\"POST\", \"https://api.example.com/upload\", body)\n" +
+				"\tif err != nil {\n" +
+				"\t\tpanic(err)\n" +
+				"\t}\n" +
+				"\treq.Header.Set(\"Content-Type\", writer.FormDataContentType())\n" +
+				"\n" +
+				"\tres, err := http.DefaultClient.Do(req)\n" +
+				"\tif err != nil {\n" +
+				"\t\tpanic(err)\n" +
+				"\t}\n" +
+				"\tdefer res.Body.Close()\n" +
+				"\n" +
+				"\tresBody, err := io.ReadAll(res.Body)\n" +
+				"\tif err != nil {\n" +
+				"\t\tpanic(err)\n" +
+				"\t}\n" +
+				"\n" +
+				"\tfmt.Println(res.Status)\n" +
+				"\tfmt.Println(string(resBody))\n" +
+				"}\n",
+		},
+		{
+			name: "success url with query",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method: "GET",
+					URL:    "https://api.example.com/users?active=true",
+					Query:  types.Map{"page": types.Int(2)},
+				},
+			},
+			expected: "package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"fmt\"\n" +
+				"\t\"io\"\n" +
+				"\t\"net/http\"\n" +
+				"\t\"net/url\"\n" +
+				")\n" +
+				"\n" +
+				"func main() {\n" +
+				"\tquery := url.Values{}\n" +
+				"\tquery.Set(\"page\", \"2\")\n" +
+				"\n" +
+				"\treq, err := http.NewRequest(\"GET\", \"https://api.example.com/users?active=true\"+\"&\"+query.Encode(), nil)\n" +
+				"\tif err != nil {\n" +
+				"\t\tpanic(err)\n" +
+				"\t}\n" +
+				"\n" +
+				"\tres, err := http.DefaultClient.Do(req)\n" +
+				"\tif err != nil {\n" +
+				"\t\tpanic(err)\n" +
+				"\t}\n" +
+				"\tdefer res.Body.Close()\n" +
+				"\n" +
+				"\tresBody, err := io.ReadAll(res.Body)\n" +
+				"\tif err != nil {\n" +
+				"\t\tpanic(err)\n" +
+				"\t}\n" +
+				"\n" +
+				"\tfmt.Println(res.Status)\n" +
+				"\tfmt.Println(string(resBody))\n" +
+				"}\n",
+		},
+		{
+			name: "error param not found",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method: "GET",
+					URL:    "https://api.example.com/users",
+					Params: types.Map{"id": types.Int(12)},
+				},
+			},
+			expectedError: errors.New("can not replace param: id"),
+		},
+	}
+
+	e := exporter.NewGo()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, err := e.Export(tc.doFile)
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if code != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, code)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package exporter
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+// javaRestrictedHeaders defines the headers that the java http client does not allow to set
+var javaRestrictedHeaders = map[string]bool{
+	"connection":     true,
+	"content-length": true,
+	"expect":         true,
+	"host":           true,
+	"upgrade":        true,
+}
+
+// javaMultipartMethod defines the method that writes a multipart body
+const javaMultipartMethod = `
+    static HttpRequest.BodyPublisher multipart(String boundary, List<Map.Entry<String, String>> fields, List<Map.Entry<String, Path>> files) throws IOException {
+        List<byte[]> parts = new ArrayList<>();
+        for (Map.Entry<String, String> field : fields) {
+            parts.add(("--" + boundary + "\r\nContent-Disposition: form-data; name=\"" + field.getKey() + "\"\r\n\r\n"
+                + field.getValue() + "\r\n").getBytes(StandardCharsets.UTF_8));
+        }
+        for (Map.Entry<String, Path> file : files) {
+            parts.add(("--" + boundary + "\r\nContent-Disposition: form-data; name=\"" + file.getKey() + "\"; filename=\""
+                + file.getValue().getFileName() + "\"\r\nContent-Type: application/octet-stream\r\n\r\n").getBytes(StandardCharsets.UTF_8));
+            parts.add(Files.readAllBytes(file.getValue()));
+            parts.add("\r\n".getBytes(StandardCharsets.UTF_8));
+        }
+        parts.add(("--" + boundary + "--\r\n").getBytes(StandardCharsets.UTF_8));
+        return HttpRequest.BodyPublishers.ofByteArrays(parts);
+    }
+`
+
+type javaExporter struct{}
+
+// NewJava returns an exporter that renders the request as a Java class using java.net.http
+func NewJava() Exporter {
+	return &javaExporter{}
+}
+
+func (e *javaExporter) Export(doFile types.DoFile) (string, error) {
+	s, err := newSnippet(doFile)
+	if err != nil {
+		return "", err
+	}
+
+	imports := []string{"java.net.URI", "java.net.http.HttpClient", "java.net.http.HttpRequest", "java.net.http.HttpResponse"}
+	code := &strings.Builder{}
+
+	uri := jsonQuote(s.url)
+	if len(s.query) > 0 {
+		imports = append(imports, "java.net.URLEncoder", "java.nio.charset.StandardCharsets")
+		pairs := make([]string, 0, len(s.query))
+		for _, q := range s.query {
+			pairs = append(pairs, "URLEncoder.encode("+jsonQuote(q.Key)+", StandardCharsets.UTF_8) + \"=\" + URLEncoder.encode("+
+				jsonQuote(q.Value)+", StandardCharsets.UTF_8)")
+		}
+		code.WriteString("        String query = " + strings.Join(pairs, "\n            + \"&\" + ") + ";\n")
+		uri += ` + "` + querySeparator(s.url) + `" + query`
+	}
+
+	publisher := "HttpRequest.BodyPublishers.noBody()"
+	switch {
+	case s.body != nil:
+		publisher = "HttpRequest.BodyPublishers.ofString(" + jsonQuote(*s.body) + ")"
+	case s.isMultipart():
+		imports = append(imports, "java.io.IOException", "java.nio.charset.StandardCharsets", "java.nio.file.Files",
+			"java.nio.file.Path", "java.util.ArrayList", "java.util.List", "java.util.Map", "java.util.UUID")
+		code.WriteString("        String boundary = UUID.randomUUID().toString();\n")
+		// the parts are lists, not maps, so they are written in the same sorted order on every run
+		code.WriteString("        List<Map.Entry<String, String>> fields = List.of(" + javaEntries(s.fields, false) + ");\n")
+		code.WriteString("        List<Map.Entry<String, Path>> files = List.of(" + javaEntries(s.files, true) + ");\n")
+		publisher = "multipart(boundary, fields, files)"
+	}
+
+	code.WriteString("        HttpRequest request = HttpRequest.newBuilder()\n")
+	code.WriteString("            .uri(URI.create(" + uri + "))\n")
+	for _, h := range s.headers {
+		if !javaRestrictedHeaders[strings.ToLower(h.Key)] {
+			code.WriteString("            .header(" + jsonQuote(h.Key) + ", " + jsonQuote(h.Value) + ")\n")
+		}
+	}
+	if s.isMultipart() {
+		code.WriteString("            .header(\"Content-Type\", \"multipart/form-data; boundary=\" + boundary)\n")
+	}
+	code.WriteString("            .method(" + jsonQuote(s.method) + ", " + publisher + ")\n            .build();\n\n")
+	code.WriteString("        HttpResponse<String> response = HttpClient.newHttpClient()\n" +
+		"            .send(request, HttpResponse.BodyHandlers.ofString());\n\n" +
+		"        System.out.println(response.statusCode());\n" +
+		"        System.out.println(response.body());\n")
+
+	source := &strings.Builder{}
+	for _, name := range sortedUnique(imports) {
+		source.WriteString("import " + name + ";\n")
+	}
+	source.WriteString("\npublic class Main {\n    public static void main(String[] args) throws Exception {\n")
+	source.WriteString(code.String())
+	source.WriteString("    }\n")
+	if s.isMultipart() {
+		source.WriteString(javaMultipartMethod)
+	}
+	source.WriteString("}\n")
+
+	return source.String(), nil
+}
+
+// javaEntries returns the arguments of List.of for the parts
+func javaEntries(parts []part, isFile bool) string {
+	entries := make([]string, 0, len(parts))
+	for _, p := range parts {
+		value := jsonQuote(p.Value)
+		if isFile {
+			value = "Path.of(" + value + ")"
+		}
+		entries = append(entries, "\n            Map.entry("+jsonQuote(p.Key)+", "+value+")")
+	}
+
+	return strings.Join(entries, ",")
+}
+
+func sortedUnique(values []string) []string {
+	unique := map[string]bool{}
+	for _, value := range values {
+		unique[value] = true
+	}
+
+	result := make([]string, 0, len(unique))
+	for value := range unique {
+		result = append(result, value)
+	}
+	sort.Strings(result)
+
+	return result
+}
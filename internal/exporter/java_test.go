@@ -0,0 +1,212 @@
+package exporter_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jibaru/do/internal/exporter"
+	"github.com/jibaru/do/internal/types"
+)
+
+func TestJavaExporter_Export(t *testing.T) {
+	testCases := []struct {
+		name          string
+		doFile        types.DoFile
+		expected      string
+		expectedError error
+	}{
+		{
+			name: "success get with query",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method:  "GET",
+					URL:     "https://api.example.com/users/:id",
+					Params:  types.Map{"id": types.Int(12)},
+					Query:   types.Map{"q": types.String("john's & co"), "page": types.Int(2)},
+					Headers: types.Map{"Accept": types.String("application/json")},
+				},
+			},
+			expected: "import java.net.URI;\n" +
+				"import java.net.URLEncoder;\n" +
+				"import java.net.http.HttpClient;\n" +
+				"import java.net.http.HttpRequest;\n" +
+				"import java.net.http.HttpResponse;\n" +
+				"import java.nio.charset.StandardCharsets;\n" +
+				"\n" +
+				"public class Main {\n" +
+				"    public static void main(String[] args) throws Exception {\n" +
+				"        String query = URLEncoder.encode(\"page\", StandardCharsets.UTF_8) + \"=\" + URLEncoder.encode(\"2\", StandardCharsets.UTF_8)\n" +
+				"            + \"&\" + URLEncoder.encode(\"q\", StandardCharsets.UTF_8) + \"=\" + URLEncoder.encode(\"john's & co\", StandardCharsets.UTF_8);\n" +
+				"        HttpRequest request = HttpRequest.newBuilder()\n" +
+				"            .uri(URI.create(\"https://api.example.com/users/12\" + \"?\" + query))\n" +
+				"            .header(\"Accept\", \"application/json\")\n" +
+				"            .method(\"GET\", HttpRequest.BodyPublishers.noBody())\n" +
+				"            .build();\n" +
+				"\n" +
+				"        HttpResponse<String> response = HttpClient.newHttpClient()\n" +
+				"            .send(request, HttpResponse.BodyHandlers.ofString());\n" +
+				"\n" +
+				"        System.out.println(response.statusCode());\n" +
+				"        System.out.println(response.body());\n" +
+				"    }\n" +
+				"}\n",
+		},
+		{
+			name: "success string body",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method:  "POST",
+					URL:     "https://api.example.com/users",
+					Headers: types.Map{"Content-Type": types.String("application/json")},
+					Body:    types.String(`{"name": "John"}`),
+				},
+			},
+			expected: "import java.net.URI;\n" +
+				"import java.net.http.HttpClient;\n" +
+				"import java.net.http.HttpRequest;\n" +
+				"import java.net.http.HttpResponse;\n" +
+				"\n" +
+				"public class Main {\n" +
+				"    public static void main(String[] args) throws Exception {\n" +
+				"        HttpRequest request = HttpRequest.newBuilder()\n" +
+				"            .uri(URI.create(\"https://api.example.com/users\"))\n" +
+				"            .header(\"Content-Type\", \"application/json\")\n" +
+				"            .method(\"POST\", HttpRequest.BodyPublishers.ofString(\"{\\\"name\\\": \\\"John\\\"}\"))\n" +
+				"            .build();\n" +
+				"\n" +
+				"        HttpResponse<String> response = HttpClient.newHttpClient()\n" +
+				"            .send(request, HttpResponse.BodyHandlers.ofString());\n" +
+				"\n" +
+				"        System.out.println(response.statusCode());\n" +
+				"        System.out.println(response.body());\n" +
+				"    }\n" +
+				"}\n",
+		},
+		{
+			name: "success multipart body",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method:  "POST",
+					URL:     "https://api.example.com/upload",
+					Headers: types.Map{"Content-Type": types.String("multipart/form-data")},
+					Body: types.Map{
+						"name":   types.String("john"),
+						"avatar": types.File{Path: "/tmp/avatar.png"},
+					},
+				},
+			},
+			expected: "import java.io.IOException;\n" +
+				"import java.net.URI;\n" +
+				"import java.net.http.HttpClient;\n" +
+				"import java.net.http.HttpRequest;\n" +
+				"import java.net.http.HttpResponse;\n" +
+				"import java.nio.charset.StandardCharsets;\n" +
+				"import java.nio.file.Files;\n" +
+				"import java.nio.file.Path;\n" +
+				"import java.util.ArrayList;\n" +
+				"import java.util.List;\n" +
+				"import java.util.Map;\n" +
+				"import java.util.UUID;\n" +
+				"\n" +
+				"public class Main {\n" +
+				"    public static void main(String[] args) throws Exception {\n" +
+				"        String boundary = UUID.randomUUID().toString();\n" +
+				"        List<Map.Entry<String, String>> fields = List.of(\n" +
+				"            Map.entry(\"name\", \"john\"));\n" +
+				"        List<Map.Entry<String, Path>> files = List.of(\n" +
+				"            Map.entry(\"avatar\", Path.of(\"/tmp/avatar.png\")));\n" +
+				"        HttpRequest request = HttpRequest.newBuilder()\n" +
+				"            .uri(URI.create(\"https://api.example.com/upload\"))\n" +
+				"            .header(\"Content-Type\", \"multipart/form-data; boundary=\" + boundary)\n" +
+				"            .method(\"POST\", multipart(boundary, fields, files))\n" +
+				"            .build();\n" +
+				"\n" +
+				"        HttpResponse<String> response = HttpClient.newHttpClient()\n" +
+				"            .send(request, HttpResponse.BodyHandlers.ofString());\n" +
+				"\n" +
+				"        System.out.println(response.statusCode());\n" +
+				"        System.out.println(response.body());\n" +
+				"    }\n" +
+				"\n" +
+				"    static HttpRequest.BodyPublisher multipart(String boundary, List<Map.Entry<String, String>> fields, List<Map.Entry<String, Path>> files) throws IOException {\n" +
+				"        List<byte[]> parts = new ArrayList<>();\n" +
+				"        for (Map.Entry<String, String> field : fields) {\n" +
+				"            parts.add((\"--\" + boundary + \"\\r\\nContent-Disposition: form-data; name=\\\"\" + field.getKey() + \"\\\"\\r\\n\\r\\n\"\n" +
+				"                + field.getValue() + \"\\r\\n\").getBytes(StandardCharsets.UTF_8));\n" +
+				"        }\n" +
+				"        for (Map.Entry<String, Path> file : files) {\n" +
+				"            parts.add((\"--\" + boundary + \"\\r\\nContent-Disposition: form-data; name=\\\"\" + file.getKey() + \"\\\"; filename=\\\"\"\n" +
+				"                + file.getValue().getFileName() + \"\\\"\\r\\nContent-Type: application/octet-stream\\r\\n\\r\\n\").getBytes(StandardCharsets.UTF_8));\n" +
+				"            parts.add(Files.readAllBytes(file.getValue()));\n" +
+				"            parts.add(\"\\r\\n\".getBytes(StandardCharsets.UTF_8));\n" +
+				"        }\n" +
+				"        parts.add((\"--\" + boundary + \"--\\r\\n\").getBytes(StandardCharsets.UTF_8));\n" +
+				"        return HttpRequest.BodyPublishers.ofByteArrays(parts);\n" +
+				"    }\n" +
+				"}\n",
+		},
+		{
+			name: "success url with query",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method: "GET",
+					URL:    "https://api.example.com/users?active=true",
+					Query:  types.Map{"page": types.Int(2)},
+				},
+			},
+			expected: "import java.net.URI;\n" +
+				"import java.net.URLEncoder;\n" +
+				"import java.net.http.HttpClient;\n" +
+				"import java.net.http.HttpRequest;\n" +
+				"import java.net.http.HttpResponse;\n" +
+				"import java.nio.charset.StandardCharsets;\n" +
+				"\n" +
+				"public class Main {\n" +
+				"    public static void main(String[] args) throws Exception {\n" +
+				"        String query = URLEncoder.encode(\"page\", StandardCharsets.UTF_8) + \"=\" + URLEncoder.encode(\"2\", StandardCharsets.UTF_8);\n" +
+				"        HttpRequest request = HttpRequest.newBuilder()\n" +
+				"            .uri(URI.create(\"https://api.example.com/users?active=true\" + \"&\" + query))\n" +
+				"            .method(\"GET\", HttpRequest.BodyPublishers.noBody())\n" +
+				"            .build();\n" +
+				"\n" +
+				"        HttpResponse<String> response = HttpClient.newHttpClient()\n" +
+				"            .send(request, HttpResponse.BodyHandlers.ofString());\n" +
+				"\n" +
+				"        System.out.println(response.statusCode());\n" +
+				"        System.out.println(response.body());\n" +
+				"    }\n" +
+				"}\n",
+		},
+		{
+			name: "error param not found",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method: "GET",
+					URL:    "https://api.example.com/users",
+					Params: types.Map{"id": types.Int(12)},
+				},
+			},
+			expectedError: errors.New("can not replace param: id"),
+		},
+	}
+
+	e := exporter.NewJava()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, err := e.Export(tc.doFile)
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if code != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, code)
+			}
+		})
+	}
+}
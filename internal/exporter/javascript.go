@@ -0,0 +1,68 @@
+package exporter
+
+import (
+	"path"
+	"strings"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+type javaScriptExporter struct{}
+
+// NewJavaScript returns an exporter that renders the request as a JavaScript module using fetch
+func NewJavaScript() Exporter {
+	return &javaScriptExporter{}
+}
+
+func (e *javaScriptExporter) Export(doFile types.DoFile) (string, error) {
+	s, err := newSnippet(doFile)
+	if err != nil {
+		return "", err
+	}
+
+	code := &strings.Builder{}
+	if len(s.files) > 0 {
+		code.WriteString("import { openAsBlob } from \"node:fs\";\n\n")
+	}
+
+	code.WriteString("const url = new URL(" + jsonQuote(s.url) + ");\n")
+	for _, q := range s.query {
+		code.WriteString("url.searchParams.append(" + jsonQuote(q.Key) + ", " + jsonQuote(q.Value) + ");\n")
+	}
+
+	options := []string{"  method: " + jsonQuote(s.method) + ","}
+	if len(s.headers) > 0 {
+		options = append(options, "  headers: "+javaScriptObject(s.headers, "  ")+",")
+	}
+
+	switch {
+	case s.body != nil:
+		options = append(options, "  body: "+jsonQuote(*s.body)+",")
+	case s.isMultipart():
+		code.WriteString("\nconst body = new FormData();\n")
+		for _, p := range s.fields {
+			code.WriteString("body.append(" + jsonQuote(p.Key) + ", " + jsonQuote(p.Value) + ");\n")
+		}
+		for _, p := range s.files {
+			code.WriteString("body.append(" + jsonQuote(p.Key) + ", await openAsBlob(" + jsonQuote(p.Value) + "), " +
+				jsonQuote(path.Base(p.Value)) + ");\n")
+		}
+		options = append(options, "  body,")
+	}
+
+	code.WriteString("\nconst response = await fetch(url, {\n" + strings.Join(options, "\n") + "\n});\n\n")
+	code.WriteString("console.log(response.status);\nconsole.log(await response.text());\n")
+
+	return code.String(), nil
+}
+
+func javaScriptObject(values []header, indentation string) string {
+	code := &strings.Builder{}
+	code.WriteString("{\n")
+	for _, value := range values {
+		code.WriteString(indentation + "  " + jsonQuote(value.Key) + ": " + jsonQuote(value.Value) + ",\n")
+	}
+	code.WriteString(indentation + "}")
+
+	return code.String()
+}
@@ -0,0 +1,146 @@
+package exporter_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jibaru/do/internal/exporter"
+	"github.com/jibaru/do/internal/types"
+)
+
+func TestJavaScriptExporter_Export(t *testing.T) {
+	testCases := []struct {
+		name          string
+		doFile        types.DoFile
+		expected      string
+		expectedError error
+	}{
+		{
+			name: "success get with query",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method:  "GET",
+					URL:     "https://api.example.com/users/:id",
+					Params:  types.Map{"id": types.Int(12)},
+					Query:   types.Map{"q": types.String("john's & co"), "page": types.Int(2)},
+					Headers: types.Map{"Accept": types.String("application/json")},
+				},
+			},
+			expected: "const url = new URL(\"https://api.example.com/users/12\");\n" +
+				"url.searchParams.append(\"page\", \"2\");\n" +
+				"url.searchParams.append(\"q\", \"john's & co\");\n" +
+				"\n" +
+				"const response = await fetch(url, {\n" +
+				"  method: \"GET\",\n" +
+				"  headers: {\n" +
+				"    \"Accept\": \"application/json\",\n" +
+				"  },\n" +
+				"});\n" +
+				"\n" +
+				"console.log(response.status);\n" +
+				"console.log(await response.text());\n",
+		},
+		{
+			name: "success string body",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method:  "POST",
+					URL:     "https://api.example.com/users",
+					Headers: types.Map{"Content-Type": types.String("application/json")},
+					Body:    types.String(`{"name": "John"}`),
+				},
+			},
+			expected: "const url = new URL(\"https://api.example.com/users\");\n" +
+				"\n" +
+				"const response = await fetch(url, {\n" +
+				"  method: \"POST\",\n" +
+				"  headers: {\n" +
+				"    \"Content-Type\": \"application/json\",\n" +
+				"  },\n" +
+				"  body: \"{\\\"name\\\": \\\"John\\\"}\",\n" +
+				"});\n" +
+				"\n" +
+				"console.log(response.status);\n" +
+				"console.log(await response.text());\n",
+		},
+		{
+			name: "success multipart body",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method:  "POST",
+					URL:     "https://api.example.com/upload",
+					Headers: types.Map{"Content-Type": types.String("multipart/form-data")},
+					Body: types.Map{
+						"name":   types.String("john"),
+						"avatar": types.File{Path: "/tmp/avatar.png"},
+					},
+				},
+			},
+			expected: "import { openAsBlob } from \"node:fs\";\n" +
+				"\n" +
+				"const url = new URL(\"https://api.example.com/upload\");\n" +
+				"\n" +
+				"const body = new FormData();\n" +
+				"body.append(\"name\", \"john\");\n" +
+				"body.append(\"avatar\", await openAsBlob(\"/tmp/avatar.png\"), \"avatar.png\");\n" +
+				"\n" +
+				"const response = await fetch(url, {\n" +
+				"  method: \"POST\",\n" +
+				"  body,\n" +
+				"});\n" +
+				"\n" +
+				"console.log(response.status);\n" +
+				"console.log(await response.text());\n",
+		},
+		{
+			name: "success url with query",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method: "GET",
+					URL:    "https://api.example.com/users?active=true",
+					Query:  types.Map{"page": types.Int(2)},
+				},
+			},
+			expected: "const url = new URL(\"https://api.example.com/users?active=true\");\n" +
+				"url.searchParams.append(\"page\", \"2\");\n" +
+				"\n" +
+				"const response = await fetch(url, {\n" +
+				"  method: \"GET\",\n" +
+				"});\n" +
+				"\n" +
+				"console.log(response.status);\n" +
+				"console.log(await response.text());\n",
+		},
+		{
+			name: "error param not found",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method: "GET",
+					URL:    "https://api.example.com/users",
+					Params: types.Map{"id": types.Int(12)},
+				},
+			},
+			expectedError: errors.New("can not replace param: id"),
+		},
+	}
+
+	e := exporter.NewJavaScript()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, err := e.Export(tc.doFile)
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if code != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, code)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package exporter
+
+import "github.com/jibaru/do/internal/types"
+
+type Mock struct {
+	ExportFn func(doFile types.DoFile) (string, error)
+}
+
+func (m *Mock) Export(doFile types.DoFile) (string, error) {
+	return m.ExportFn(doFile)
+}
@@ -0,0 +1,78 @@
+package exporter
+
+import (
+	"strings"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+// pythonMethods defines the methods that have a function in requests
+var pythonMethods = map[string]bool{"GET": true, "POST": true, "PUT": true, "PATCH": true, "DELETE": true, "HEAD": true, "OPTIONS": true}
+
+type pythonExporter struct{}
+
+// NewPython returns an exporter that renders the request as a Python script using requests
+func NewPython() Exporter {
+	return &pythonExporter{}
+}
+
+func (e *pythonExporter) Export(doFile types.DoFile) (string, error) {
+	s, err := newSnippet(doFile)
+	if err != nil {
+		return "", err
+	}
+
+	code := &strings.Builder{}
+	code.WriteString("import requests\n\n")
+	code.WriteString("url = " + jsonQuote(s.url) + "\n")
+
+	args := []string{"url"}
+	if len(s.query) > 0 {
+		code.WriteString("params = " + pythonDict(s.query) + "\n")
+		args = append(args, "params=params")
+	}
+
+	if len(s.headers) > 0 {
+		code.WriteString("headers = " + pythonDict(s.headers) + "\n")
+		args = append(args, "headers=headers")
+	}
+
+	if s.body != nil {
+		code.WriteString("data = " + jsonQuote(*s.body) + "\n")
+		args = append(args, "data=data")
+	}
+
+	if s.isMultipart() {
+		// the fields are parts without a filename, so the body is multipart even without files
+		code.WriteString("files = {\n")
+		for _, p := range s.fields {
+			code.WriteString("    " + jsonQuote(p.Key) + ": (None, " + jsonQuote(p.Value) + "),\n")
+		}
+		for _, p := range s.files {
+			code.WriteString("    " + jsonQuote(p.Key) + ": open(" + jsonQuote(p.Value) + ", \"rb\"),\n")
+		}
+		code.WriteString("}\n")
+		args = append(args, "files=files")
+	}
+
+	call := "requests.request(" + jsonQuote(s.method) + ", "
+	if pythonMethods[s.method] {
+		call = "requests." + strings.ToLower(s.method) + "("
+	}
+
+	code.WriteString("\nresponse = " + call + strings.Join(args, ", ") + ")\n\n")
+	code.WriteString("print(response.status_code)\nprint(response.text)\n")
+
+	return code.String(), nil
+}
+
+func pythonDict(values []header) string {
+	code := &strings.Builder{}
+	code.WriteString("{\n")
+	for _, value := range values {
+		code.WriteString("    " + jsonQuote(value.Key) + ": " + jsonQuote(value.Value) + ",\n")
+	}
+	code.WriteString("}")
+
+	return code.String()
+}
@@ -0,0 +1,180 @@
+package exporter_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jibaru/do/internal/exporter"
+	"github.com/jibaru/do/internal/types"
+)
+
+func TestPythonExporter_Export(t *testing.T) {
+	testCases := []struct {
+		name          string
+		doFile        types.DoFile
+		expected      string
+		expectedError error
+	}{
+		{
+			name: "success get with query",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method:  "GET",
+					URL:     "https://api.example.com/users/:id",
+					Params:  types.Map{"id": types.Int(12)},
+					Query:   types.Map{"q": types.String("john's & co"), "page": types.Int(2)},
+					Headers: types.Map{"Accept": types.String("application/json")},
+				},
+			},
+			expected: "import requests\n" +
+				"\n" +
+				"url = \"https://api.example.com/users/12\"\n" +
+				"params = {\n" +
+				"    \"page\": \"2\",\n" +
+				"    \"q\": \"john's & co\",\n" +
+				"}\n" +
+				"headers = {\n" +
+				"    \"Accept\": \"application/json\",\n" +
+				"}\n" +
+				"\n" +
+				"response = requests.get(url, params=params, headers=headers)\n" +
+				"\n" +
+				"print(response.status_code)\n" +
+				"print(response.text)\n",
+		},
+		{
+			name: "success string body",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method:  "POST",
+					URL:     "https://api.example.com/users",
+					Headers: types.Map{"Content-Type": types.String("application/json")},
+					Body:    types.String(`{"name": "John"}`),
+				},
+			},
+			expected: "import requests\n" +
+				"\n" +
+				"url = \"https://api.example.com/users\"\n" +
+				"headers = {\n" +
+				"    \"Content-Type\": \"application/json\",\n" +
+				"}\n" +
+				"data = \"{\\\"name\\\": \\\"John\\\"}\"\n" +
+				"\n" +
+				"response = requests.post(url, headers=headers, data=data)\n" +
+				"\n" +
+				"print(response.status_code)\n" +
+				"print(response.text)\n",
+		},
+		{
+			name: "success multipart body",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method:  "POST",
+					URL:     "https://api.example.com/upload",
+					Headers: types.Map{"Content-Type": types.String("multipart/form-data")},
+					Body: types.Map{
+						"name":   types.String("john"),
+						"avatar": types.File{Path: "/tmp/avatar.png"},
+					},
+				},
+			},
+			expected: "import requests\n" +
+				"\n" +
+				"url = \"https://api.example.com/upload\"\n" +
+				"files = {\n" +
+				"    \"name\": (None, \"john\"),\n" +
+				"    \"avatar\": open(\"/tmp/avatar.png\", \"rb\"),\n" +
+				"}\n" +
+				"\n" +
+				"response = requests.post(url, files=files)\n" +
+				"\n" +
+				"print(response.status_code)\n" +
+				"print(response.text)\n",
+		},
+		{
+			name: "success multipart without files",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method: "POST",
+					URL:    "https://api.example.com/users",
+					Body:   types.Map{"name": types.String("john"), "role": types.String("admin")},
+				},
+			},
+			expected: "import requests\n" +
+				"\n" +
+				"url = \"https://api.example.com/users\"\n" +
+				"files = {\n" +
+				"    \"name\": (None, \"john\"),\n" +
+				"    \"role\": (None, \"admin\"),\n" +
+				"}\n" +
+				"\n" +
+				"response = requests.post(url, files=files)\n" +
+				"\n" +
+				"print(response.status_code)\n" +
+				"print(response.text)\n",
+		},
+		{
+			name: "success url with query",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method: "GET",
+					URL:    "https://api.example.com/users?active=true",
+					Query:  types.Map{"page": types.Int(2)},
+				},
+			},
+			expected: "import requests\n" +
+				"\n" +
+				"url = \"https://api.example.com/users?active=true\"\n" +
+				"params = {\n" +
+				"    \"page\": \"2\",\n" +
+				"}\n" +
+				"\n" +
+				"response = requests.get(url, params=params)\n" +
+				"\n" +
+				"print(response.status_code)\n" +
+				"print(response.text)\n",
+		},
+		{
+			name: "error digest auth",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method: "GET",
+					URL:    "https://api.example.com/me",
+					Auth:   &types.Auth{Type: types.AuthDigest, Username: "admin", Password: "secret"},
+				},
+			},
+			expectedError: errors.New("digest auth is not supported by this format"),
+		},
+		{
+			name: "error param not found",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method: "GET",
+					URL:    "https://api.example.com/users",
+					Params: types.Map{"id": types.Int(12)},
+				},
+			},
+			expectedError: errors.New("can not replace param: id"),
+		},
+	}
+
+	e := exporter.NewPython()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, err := e.Export(tc.doFile)
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if code != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, code)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package formatter
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+const indentation = "    "
+
+var assignmentRegexp = regexp.MustCompile(`^([A-Za-z_]\w*)\s*=\s*(.*)$`)
+
+type Formatter interface {
+	// Format returns the content of a .do file with a canonical layout, keeping the comments.
+	Format(content types.FileReaderContent) (types.FileReaderContent, error)
+}
+
+type formatter struct{}
+
+func New() Formatter {
+	return &formatter{}
+}
+
+func (f *formatter) Format(content types.FileReaderContent) (types.FileReaderContent, error) {
+	lines := strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+	result := make([]string, 0, len(lines))
+
+	depth := 0
+	inQuotes := false
+	inBackticks := false
+	lastClosedBlock := false
+
+	for _, line := range lines {
+		if inBackticks {
+			// multiline strings are kept untouched
+			result = append(result, strings.TrimRight(line, " \t"))
+			depth, inQuotes, inBackticks = advance(line, depth, inQuotes, inBackticks)
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			if len(result) > 0 && result[len(result)-1] != "" {
+				result = append(result, "")
+			}
+			continue
+		}
+
+		if depth == 0 && lastClosedBlock && len(result) > 0 && result[len(result)-1] != "" {
+			result = append(result, "")
+		}
+
+		lineDepth := depth
+		if strings.HasPrefix(trimmed, "}") && lineDepth > 0 {
+			lineDepth--
+		}
+
+		if depth == 1 {
+			if matches := assignmentRegexp.FindStringSubmatch(trimmed); matches != nil {
+				trimmed = matches[1] + " = " + matches[2]
+			}
+		}
+
+		result = append(result, strings.Repeat(indentation, lineDepth)+trimmed)
+
+		depth, inQuotes, inBackticks = advance(line, depth, inQuotes, inBackticks)
+		lastClosedBlock = depth == 0 && strings.HasSuffix(trimmed, "}")
+	}
+
+	for len(result) > 0 && result[len(result)-1] == "" {
+		result = result[:len(result)-1]
+	}
+
+	return types.FileReaderContent(strings.Join(result, "\n") + "\n"), nil
+}
+
+// advance returns the state of the braces and strings after reading the line
+func advance(line string, depth int, inQuotes, inBackticks bool) (int, bool, bool) {
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+
+		if ch == '"' && !inBackticks {
+			inQuotes = !inQuotes
+		}
+		if ch == '`' && !inQuotes {
+			inBackticks = !inBackticks
+		}
+		if inQuotes || inBackticks {
+			continue
+		}
+
+		if ch == '/' && i+1 < len(line) && line[i+1] == '/' {
+			break
+		}
+		if ch == '{' {
+			depth++
+		}
+		if ch == '}' && depth > 0 {
+			depth--
+		}
+	}
+
+	return depth, inQuotes, inBackticks
+}
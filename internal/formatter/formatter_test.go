@@ -0,0 +1,47 @@
+package formatter_test
+
+import (
+	"testing"
+
+	"github.com/jibaru/do/internal/formatter"
+	"github.com/jibaru/do/internal/types"
+)
+
+func TestFormatter_Format(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  types.FileReaderContent
+		expected types.FileReaderContent
+	}{
+		{
+			name:     "indentation and spacing",
+			content:  "\n\nlet {\nvar1=1;\n      var2   =  \"hello  world\";   \n}\ndo {\n  method=\"GET\";\nurl = \"http://localhost\";\n headers = {\n\"Accept\": \"*/*\"\n};\n}\n\n\n",
+			expected: "let {\n    var1 = 1;\n    var2 = \"hello  world\";\n}\n\ndo {\n    method = \"GET\";\n    url = \"http://localhost\";\n    headers = {\n        \"Accept\": \"*/*\"\n    };\n}\n",
+		},
+		{
+			name:     "comments and blank lines",
+			content:  "// request\ndo {\n// the method\n   method = \"GET\"; // inline { comment\n\n\n\n   url = \"http://localhost\";\n}",
+			expected: "// request\ndo {\n    // the method\n    method = \"GET\"; // inline { comment\n\n    url = \"http://localhost\";\n}\n",
+		},
+		{
+			name:     "multiline strings are not changed",
+			content:  "do {\nmethod = \"POST\";\nurl = \"http://localhost\";\nbody = `{\n  \"name\": \"john\",\n      \"age\": 20\n}`;\n}\n",
+			expected: "do {\n    method = \"POST\";\n    url = \"http://localhost\";\n    body = `{\n  \"name\": \"john\",\n      \"age\": 20\n}`;\n}\n",
+		},
+	}
+
+	f := formatter.New()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			formatted, err := f.Format(tc.content)
+			if err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+
+			if formatted != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, formatted)
+			}
+		})
+	}
+}
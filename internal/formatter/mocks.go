@@ -0,0 +1,11 @@
+package formatter
+
+import "github.com/jibaru/do/internal/types"
+
+type Mock struct {
+	FormatFn func(content types.FileReaderContent) (types.FileReaderContent, error)
+}
+
+func (m *Mock) Format(content types.FileReaderContent) (types.FileReaderContent, error) {
+	return m.FormatFn(content)
+}
@@ -0,0 +1,13 @@
+package har
+
+type InvalidArchiveError struct {
+	reason string
+}
+
+func NewInvalidArchiveError(reason string) error {
+	return InvalidArchiveError{reason}
+}
+
+func (e InvalidArchiveError) Error() string {
+	return "invalid har archive: " + e.reason
+}
@@ -0,0 +1,341 @@
+package har
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+const (
+	// Version defines the version of the HAR format written
+	Version = "1.2"
+	// CreatorName defines the name of the tool that creates the archives
+	CreatorName = "do"
+	// defaultHTTPVersion is used when the protocol of the response is unknown
+	defaultHTTPVersion = "HTTP/1.1"
+)
+
+// HAR defines an HTTP archive
+type HAR struct {
+	Log Log `json:"log"`
+}
+
+// Log defines the exchanges recorded in an archive
+type Log struct {
+	Version string  `json:"version"`
+	Creator Creator `json:"creator"`
+	Entries []Entry `json:"entries"`
+}
+
+// Creator defines the tool that created an archive
+type Creator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// Entry defines a request and its response
+type Entry struct {
+	StartedDateTime time.Time `json:"startedDateTime"`
+	Time            float64   `json:"time"`
+	Request         Request   `json:"request"`
+	Response        Response  `json:"response"`
+	Cache           struct{}  `json:"cache"`
+	Timings         Timings   `json:"timings"`
+}
+
+// Request defines a request of an entry
+type Request struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	HTTPVersion string      `json:"httpVersion"`
+	Cookies     []Cookie    `json:"cookies"`
+	Headers     []NameValue `json:"headers"`
+	QueryString []NameValue `json:"queryString"`
+	PostData    *PostData   `json:"postData,omitempty"`
+	HeadersSize int         `json:"headersSize"`
+	BodySize    int         `json:"bodySize"`
+}
+
+// Response defines the response of an entry
+type Response struct {
+	Status      int         `json:"status"`
+	StatusText  string      `json:"statusText"`
+	HTTPVersion string      `json:"httpVersion"`
+	Cookies     []Cookie    `json:"cookies"`
+	Headers     []NameValue `json:"headers"`
+	Content     Content     `json:"content"`
+	RedirectURL string      `json:"redirectURL"`
+	HeadersSize int         `json:"headersSize"`
+	BodySize    int         `json:"bodySize"`
+}
+
+// Cookie defines a cookie sent or received
+type Cookie struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Path     string `json:"path,omitempty"`
+	Domain   string `json:"domain,omitempty"`
+	HTTPOnly bool   `json:"httpOnly,omitempty"`
+	Secure   bool   `json:"secure,omitempty"`
+}
+
+// NameValue defines a header or a query param
+type NameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// PostData defines the body of a request
+type PostData struct {
+	MimeType string  `json:"mimeType"`
+	Params   []Param `json:"params,omitempty"`
+	Text     string  `json:"text"`
+}
+
+// Param defines a field of a form body
+type Param struct {
+	Name        string `json:"name"`
+	Value       string `json:"value,omitempty"`
+	FileName    string `json:"fileName,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// Content defines the body of a response
+type Content struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// Timings defines the milliseconds spent in each phase of a request, -1 when the phase did not happen
+type Timings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
+
+// New returns an archive created by the given version of do with the entries
+func New(version string, entries ...Entry) *HAR {
+	return &HAR{
+		Log: Log{
+			Version: Version,
+			Creator: Creator{Name: CreatorName, Version: version},
+			Entries: append(make([]Entry, 0, len(entries)), entries...),
+		},
+	}
+}
+
+// Parse returns the archive described by the content
+func Parse(content []byte) (*HAR, error) {
+	archive := &HAR{}
+	if err := json.Unmarshal(content, archive); err != nil {
+		return nil, NewInvalidArchiveError(err.Error())
+	}
+
+	if archive.Log.Entries == nil {
+		return nil, NewInvalidArchiveError("log entries not found")
+	}
+
+	return archive, nil
+}
+
+// MarshalIndent returns the JSON representation of the archive
+func (h HAR) MarshalIndent() string {
+	value, _ := json.MarshalIndent(h, "", "  ")
+	return string(value)
+}
+
+// Append returns the archive described by the content with the entries added at the end. The
+// archive is not decoded into a HAR, so the fields it does not define, like the pages, the browser
+// or the custom fields of the entries, are kept as they are.
+func Append(content []byte, entries ...Entry) ([]byte, error) {
+	var archive map[string]json.RawMessage
+	if err := json.Unmarshal(content, &archive); err != nil {
+		return nil, NewInvalidArchiveError(err.Error())
+	}
+
+	var log map[string]json.RawMessage
+	if err := json.Unmarshal(archive["log"], &log); err != nil || log == nil {
+		return nil, NewInvalidArchiveError("log not found")
+	}
+
+	var current []json.RawMessage
+	if err := json.Unmarshal(log["entries"], &current); err != nil || current == nil {
+		return nil, NewInvalidArchiveError("log entries not found")
+	}
+
+	for _, entry := range entries {
+		value, err := json.Marshal(entry)
+		if err != nil {
+			return nil, err
+		}
+		current = append(current, value)
+	}
+
+	var err error
+	if log["entries"], err = json.Marshal(current); err != nil {
+		return nil, err
+	}
+
+	if archive["log"], err = json.Marshal(log); err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(archive, "", "  ")
+}
+
+// NewEntry returns the entry of the request sent and the response received
+func NewEntry(request types.Request, response types.Response) Entry {
+	requestHeaders := http.Header(toHeader(request.Headers))
+	responseHeaders := http.Header(toHeader(response.Headers))
+
+	httpVersion := response.Proto
+	if httpVersion == "" {
+		httpVersion = defaultHTTPVersion
+	}
+
+	entry := Entry{
+		StartedDateTime: request.StartedAt,
+		Request: Request{
+			Method:      request.Method,
+			URL:         request.URL,
+			HTTPVersion: httpVersion,
+			Cookies:     toCookies((&http.Request{Header: requestHeaders}).Cookies()),
+			Headers:     toNameValues(requestHeaders),
+			QueryString: queryString(request.URL),
+			HeadersSize: -1,
+			BodySize:    len(request.Body),
+		},
+		Response: Response{
+			Status:      response.StatusCode,
+			StatusText:  http.StatusText(response.StatusCode),
+			HTTPVersion: httpVersion,
+			Cookies:     toCookies((&http.Response{Header: responseHeaders}).Cookies()),
+			Headers:     toNameValues(responseHeaders),
+			Content:     content(response.Body, responseHeaders.Get("Content-Type")),
+			RedirectURL: responseHeaders.Get("Location"),
+			HeadersSize: -1,
+			BodySize:    len(response.Body),
+		},
+		Timings: Timings{
+			Blocked: response.Timings.Blocked,
+			DNS:     response.Timings.DNS,
+			Connect: response.Timings.Connect,
+			Send:    response.Timings.Send,
+			Wait:    response.Timings.Wait,
+			Receive: response.Timings.Receive,
+			SSL:     response.Timings.SSL,
+		},
+	}
+
+	if length, err := strconv.Atoi(requestHeaders.Get("Content-Length")); err == nil {
+		entry.Request.BodySize = length
+	}
+
+	if len(request.Form) > 0 {
+		// the body of a multipart request is described by its params, its text is only a summary
+		params := make([]Param, 0, len(request.Form))
+		for _, field := range request.Form {
+			params = append(params, Param{Name: field.Name, Value: field.Value, FileName: field.FileName})
+		}
+		entry.Request.PostData = &PostData{MimeType: requestHeaders.Get("Content-Type"), Params: params}
+	} else if request.Body != "" {
+		entry.Request.PostData = &PostData{MimeType: requestHeaders.Get("Content-Type"), Text: request.Body}
+	}
+
+	// the ssl time is already part of the connect time
+	for _, timing := range []float64{entry.Timings.Blocked, entry.Timings.DNS, entry.Timings.Connect,
+		entry.Timings.Send, entry.Timings.Wait, entry.Timings.Receive} {
+		if timing > 0 {
+			entry.Time += timing
+		}
+	}
+
+	return entry
+}
+
+// toHeader returns the headers with their values as a list of strings
+func toHeader(headers map[string]interface{}) map[string][]string {
+	result := make(map[string][]string, len(headers))
+	for key, value := range headers {
+		switch val := value.(type) {
+		case []string:
+			result[key] = val
+		case []interface{}:
+			for _, item := range val {
+				result[key] = append(result[key], fmt.Sprintf("%v", item))
+			}
+		default:
+			result[key] = []string{fmt.Sprintf("%v", val)}
+		}
+	}
+
+	return result
+}
+
+// toNameValues returns the values sorted by name
+func toNameValues(values map[string][]string) []NameValue {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]NameValue, 0, len(values))
+	for _, name := range names {
+		for _, value := range values[name] {
+			result = append(result, NameValue{Name: name, Value: value})
+		}
+	}
+
+	return result
+}
+
+func toCookies(cookies []*http.Cookie) []Cookie {
+	result := make([]Cookie, 0, len(cookies))
+	for _, cookie := range cookies {
+		result = append(result, Cookie{
+			Name:     cookie.Name,
+			Value:    cookie.Value,
+			Path:     cookie.Path,
+			Domain:   cookie.Domain,
+			HTTPOnly: cookie.HttpOnly,
+			Secure:   cookie.Secure,
+		})
+	}
+
+	return result
+}
+
+func queryString(rawURL string) []NameValue {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return make([]NameValue, 0)
+	}
+
+	return toNameValues(parsedURL.Query())
+}
+
+// content returns the body as text, encoded in base64 when it is not valid utf-8
+func content(body, mimeType string) Content {
+	result := Content{Size: len(body), MimeType: mimeType, Text: body}
+	if !utf8.ValidString(body) {
+		result.Text = base64.StdEncoding.EncodeToString([]byte(body))
+		result.Encoding = "base64"
+	}
+
+	return result
+}
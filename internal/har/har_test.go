@@ -0,0 +1,245 @@
+package har_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jibaru/do/internal/har"
+	"github.com/jibaru/do/internal/types"
+)
+
+func TestNewEntry(t *testing.T) {
+	startedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	entry := har.NewEntry(
+		types.Request{
+			Method: "POST",
+			URL:    "http://localhost:8080/users?page=2&active=true",
+			Headers: map[string]interface{}{
+				"Content-Type":   []string{"application/json"},
+				"Content-Length": []string{"15"},
+				"Cookie":         []string{"session=abc"},
+			},
+			Body:      `{"name":"john"}`,
+			StartedAt: startedAt,
+		},
+		types.Response{
+			StatusCode: 201,
+			Body:       "\xff\xfe",
+			Headers: map[string]interface{}{
+				"Content-Type": []string{"application/octet-stream"},
+				"Set-Cookie":   []string{"token=xyz; Path=/; HttpOnly"},
+			},
+			Proto:   "HTTP/1.1",
+			Timings: types.Timings{Blocked: 1, DNS: 2, Connect: 3, SSL: -1, Send: 0.5, Wait: 10, Receive: 0.25},
+		},
+	)
+
+	expected := har.Entry{
+		StartedDateTime: startedAt,
+		Time:            16.75,
+		Request: har.Request{
+			Method:      "POST",
+			URL:         "http://localhost:8080/users?page=2&active=true",
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []har.Cookie{{Name: "session", Value: "abc"}},
+			Headers: []har.NameValue{
+				{Name: "Content-Length", Value: "15"},
+				{Name: "Content-Type", Value: "application/json"},
+				{Name: "Cookie", Value: "session=abc"},
+			},
+			QueryString: []har.NameValue{
+				{Name: "active", Value: "true"},
+				{Name: "page", Value: "2"},
+			},
+			PostData:    &har.PostData{MimeType: "application/json", Text: `{"name":"john"}`},
+			HeadersSize: -1,
+			BodySize:    15,
+		},
+		Response: har.Response{
+			Status:      201,
+			StatusText:  "Created",
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []har.Cookie{{Name: "token", Value: "xyz", Path: "/", HTTPOnly: true}},
+			Headers: []har.NameValue{
+				{Name: "Content-Type", Value: "application/octet-stream"},
+				{Name: "Set-Cookie", Value: "token=xyz; Path=/; HttpOnly"},
+			},
+			Content:     har.Content{Size: 2, MimeType: "application/octet-stream", Text: "//4=", Encoding: "base64"},
+			HeadersSize: -1,
+			BodySize:    2,
+		},
+		Timings: har.Timings{Blocked: 1, DNS: 2, Connect: 3, Send: 0.5, Wait: 10, Receive: 0.25, SSL: -1},
+	}
+
+	if !reflect.DeepEqual(entry, expected) {
+		t.Errorf("expected %#v, got %#v", expected, entry)
+	}
+}
+
+func TestNewEntry_Multipart(t *testing.T) {
+	entry := har.NewEntry(
+		types.Request{
+			Method: "POST",
+			URL:    "http://localhost:8080/upload",
+			Headers: map[string]interface{}{
+				"Content-Type": []string{"multipart/form-data; boundary=abc"},
+			},
+			Body: "multipart/form-data: file=@testdata/upload.txt (12 bytes); name=john",
+			Form: []types.FormField{
+				{Name: "file", FileName: "testdata/upload.txt"},
+				{Name: "name", Value: "john"},
+			},
+		},
+		types.Response{StatusCode: 201},
+	)
+
+	expected := &har.PostData{
+		MimeType: "multipart/form-data; boundary=abc",
+		Params: []har.Param{
+			{Name: "file", FileName: "testdata/upload.txt"},
+			{Name: "name", Value: "john"},
+		},
+	}
+	if !reflect.DeepEqual(entry.Request.PostData, expected) {
+		t.Errorf("expected %#v, got %#v", expected, entry.Request.PostData)
+	}
+}
+
+func TestAppend(t *testing.T) {
+	entry := har.Entry{Request: har.Request{Method: "GET", URL: "http://localhost:8080/users"}}
+	browser := `{
+		"log": {
+			"version": "1.2",
+			"creator": {"name": "WebInspector", "version": "537.36"},
+			"browser": {"name": "Chrome", "version": "120"},
+			"comment": "exported",
+			"pages": [{"id": "page_1", "title": "Users", "pageTimings": {"onLoad": 120}}],
+			"entries": [{"pageref": "page_1", "serverIPAddress": "127.0.0.1", "connection": "42", "cache": {"beforeRequest": null}, "_initiator": {"type": "script"}}]
+		}
+	}`
+
+	testCases := []struct {
+		name          string
+		content       string
+		expected      string
+		expectedError error
+	}{
+		{
+			name:    "success keeps the fields of the archive",
+			content: browser,
+			expected: `{
+				"log": {
+					"version": "1.2",
+					"creator": {"name": "WebInspector", "version": "537.36"},
+					"browser": {"name": "Chrome", "version": "120"},
+					"comment": "exported",
+					"pages": [{"id": "page_1", "title": "Users", "pageTimings": {"onLoad": 120}}],
+					"entries": [
+						{"pageref": "page_1", "serverIPAddress": "127.0.0.1", "connection": "42", "cache": {"beforeRequest": null}, "_initiator": {"type": "script"}},
+						` + marshal(t, entry) + `
+					]
+				}
+			}`,
+		},
+		{
+			name:          "error without entries",
+			content:       `{"log": {"version": "1.2"}}`,
+			expectedError: errors.New("invalid har archive: log entries not found"),
+		},
+		{
+			name:          "error without log",
+			content:       `{}`,
+			expectedError: errors.New("invalid har archive: log not found"),
+		},
+		{
+			name:          "error invalid json",
+			content:       `{"log":`,
+			expectedError: errors.New("invalid har archive: unexpected end of JSON input"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content, err := har.Append([]byte(tc.content), entry)
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if tc.expectedError != nil {
+				return
+			}
+
+			var archive, expected interface{}
+			if err = json.Unmarshal(content, &archive); err != nil {
+				t.Fatalf("expected a json archive, got %v", err)
+			}
+			if err = json.Unmarshal([]byte(tc.expected), &expected); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(archive, expected) {
+				t.Errorf("expected %v, got %v", expected, archive)
+			}
+		})
+	}
+}
+
+func marshal(t *testing.T, value interface{}) string {
+	content, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(content)
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name          string
+		content       string
+		expected      *har.HAR
+		expectedError error
+	}{
+		{
+			name:     "success",
+			content:  har.New("test").MarshalIndent(),
+			expected: har.New("test"),
+		},
+		{
+			name:          "error without entries",
+			content:       `{"log": {"version": "1.2"}}`,
+			expectedError: errors.New("invalid har archive: log entries not found"),
+		},
+		{
+			name:          "error invalid json",
+			content:       `{"log":`,
+			expectedError: errors.New("invalid har archive: unexpected end of JSON input"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			archive, err := har.Parse([]byte(tc.content))
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(archive, tc.expected) {
+				t.Errorf("expected %#v, got %#v", tc.expected, archive)
+			}
+		})
+	}
+}
@@ -0,0 +1,346 @@
+package importer
+
+import (
+	"encoding/base64"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+// curlFilename defines the name of the file generated from a curl command
+const curlFilename = "request.do"
+
+var (
+	// curlOptionsWithValue defines the supported options that take a value
+	curlOptionsWithValue = map[string]string{
+		"-X": "request", "--request": "request",
+		"-H": "header", "--header": "header",
+		"-d": "data", "--data": "data", "--data-ascii": "data", "--data-binary": "data",
+		"--data-raw":       "data-raw",
+		"--data-urlencode": "data-urlencode",
+		"-F":               "form", "--form": "form",
+		"--form-string": "form-string",
+		"-u":            "user", "--user": "user",
+		"--url": "url",
+		"-A":    "user-agent", "--user-agent": "user-agent",
+		"-e": "referer", "--referer": "referer",
+		"-b": "cookie", "--cookie": "cookie",
+	}
+
+	// curlFlags defines the supported options that do not take a value
+	curlFlags = map[string]string{
+		"-G": "get", "--get": "get",
+		"-I": "head", "--head": "head",
+	}
+
+	// curlIgnoredFlags defines the options without value that do not change the request
+	curlIgnoredFlags = map[string]bool{
+		"-s": true, "--silent": true, "-S": true, "--show-error": true, "-L": true, "--location": true,
+		"-k": true, "--insecure": true, "-i": true, "--include": true, "-v": true, "--verbose": true,
+		"-f": true, "--fail": true, "-N": true, "--no-buffer": true, "-g": true, "--globoff": true,
+		"--compressed": true, "--http1.1": true, "--http2": true, "-#": true, "--progress-bar": true,
+	}
+
+	// curlIgnoredOptionsWithValue defines the options with value that can not be converted
+	curlIgnoredOptionsWithValue = map[string]bool{
+		"-o": true, "--output": true, "-m": true, "--max-time": true, "--connect-timeout": true,
+		"--retry": true, "-x": true, "--proxy": true, "-w": true, "--write-out": true, "--cacert": true,
+		"-E": true, "--cert": true, "--key": true, "-c": true, "--cookie-jar": true, "--resolve": true,
+		"--max-redirs": true, "-T": true, "--upload-file": true,
+	}
+)
+
+// curlCommand defines the values read from a curl command
+type curlCommand struct {
+	method  string
+	url     string
+	headers types.Map
+	data    []string
+	form    types.Map
+	user    string
+	get     bool
+	head    bool
+}
+
+type curlImporter struct{}
+
+// NewCurl returns an importer that converts a curl command into a .do file
+func NewCurl() Importer {
+	return &curlImporter{}
+}
+
+func (i *curlImporter) Import(content []byte) (*Result, error) {
+	words, err := shellSplit(string(content))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(words) > 0 && words[0] == "curl" {
+		words = words[1:]
+	}
+
+	cmd := curlCommand{headers: types.Map{}, form: types.Map{}}
+	warnings := make([]string, 0)
+
+	for idx := 0; idx < len(words); idx++ {
+		word := words[idx]
+
+		if !strings.HasPrefix(word, "-") || word == "-" {
+			cmd.url = word
+			continue
+		}
+
+		if name, ok := curlFlags[word]; ok {
+			cmd.setFlag(name)
+			continue
+		}
+
+		if curlIgnoredFlags[word] {
+			continue
+		}
+
+		name, value, ok := "", "", false
+		if name, ok = curlOptionsWithValue[word]; ok || curlIgnoredOptionsWithValue[word] {
+			if idx+1 >= len(words) {
+				return nil, NewInvalidCommandError("missing value for option " + word)
+			}
+			idx++
+			value = words[idx]
+		} else if !strings.HasPrefix(word, "--") && len(word) > 2 {
+			// short options can be joined with their value (-XPOST) or with other flags (-sSL)
+			if name, ok = curlOptionsWithValue[word[:2]]; ok {
+				value = word[2:]
+			} else if cmd.setShortFlags(word) {
+				continue
+			}
+		}
+
+		if !ok {
+			warnings = append(warnings, "option "+word+" was ignored")
+			continue
+		}
+
+		if warning := cmd.setOption(name, value); warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	doFile, queryWarnings, err := cmd.toDoFile()
+	if err != nil {
+		return nil, err
+	}
+
+	rendered, err := render(*doFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Files:    []File{{Path: curlFilename, Content: rendered}},
+		Warnings: append(warnings, queryWarnings...),
+	}, nil
+}
+
+func (c *curlCommand) setFlag(name string) {
+	switch name {
+	case "get":
+		c.get = true
+	case "head":
+		c.head = true
+	}
+}
+
+// setShortFlags sets joined short flags like -sSL, returning false when one of them is unknown
+func (c *curlCommand) setShortFlags(word string) bool {
+	for _, ch := range word[1:] {
+		flag := "-" + string(ch)
+		if _, ok := curlFlags[flag]; !ok && !curlIgnoredFlags[flag] {
+			return false
+		}
+	}
+
+	for _, ch := range word[1:] {
+		c.setFlag(curlFlags["-"+string(ch)])
+	}
+
+	return true
+}
+
+// setOption sets the value of an option, returning a warning when it can not be fully converted
+func (c *curlCommand) setOption(name, value string) string {
+	switch name {
+	case "request":
+		c.method = strings.ToUpper(value)
+	case "header":
+		parts := strings.SplitN(value, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[1]) == "" {
+			return "header " + value + " was ignored"
+		}
+		c.headers[strings.TrimSpace(parts[0])] = types.String(strings.TrimSpace(parts[1]))
+	case "data":
+		if strings.HasPrefix(value, "@") {
+			return "data from file " + value[1:] + " was not imported"
+		}
+		c.data = append(c.data, value)
+	case "data-raw":
+		c.data = append(c.data, value)
+	case "data-urlencode":
+		if idx := strings.Index(value, "="); idx != -1 {
+			c.data = append(c.data, value[:idx+1]+urlEncode(value[idx+1:]))
+		} else if strings.Contains(value, "@") {
+			return "data from file " + value + " was not imported"
+		} else {
+			c.data = append(c.data, urlEncode(value))
+		}
+	case "form":
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 {
+			return "form " + value + " was ignored"
+		}
+		if strings.HasPrefix(parts[1], "@") || strings.HasPrefix(parts[1], "<") {
+			path := strings.SplitN(parts[1][1:], ";", 2)[0]
+			c.form[parts[0]] = types.File{Path: path}
+		} else {
+			c.form[parts[0]] = types.String(parts[1])
+		}
+	case "form-string":
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 {
+			return "form " + value + " was ignored"
+		}
+		c.form[parts[0]] = types.String(parts[1])
+	case "user":
+		if !strings.Contains(value, ":") {
+			c.user = value + ":"
+			return "password for user " + value + " is empty"
+		}
+		c.user = value
+	case "url":
+		c.url = value
+	case "user-agent":
+		c.headers["User-Agent"] = types.String(value)
+	case "referer":
+		c.headers["Referer"] = types.String(value)
+	case "cookie":
+		if !strings.Contains(value, "=") {
+			return "cookies from file " + value + " were not imported"
+		}
+		c.headers["Cookie"] = types.String(value)
+	}
+
+	return ""
+}
+
+// toDoFile returns the do file equivalent to the command, with the query params
+// split out of the url and basic auth as a header
+func (c *curlCommand) toDoFile() (*types.DoFile, []string, error) {
+	if c.url == "" {
+		return nil, nil, NewMissingURLError()
+	}
+
+	rawURL := c.url
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "http://" + rawURL
+	}
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, nil, NewInvalidCommandError(err.Error())
+	}
+
+	values := parsedURL.Query()
+	parsedURL.RawQuery = ""
+	parsedURL.Fragment = ""
+
+	data := strings.Join(c.data, "&")
+	if c.get && data != "" {
+		dataValues, err := url.ParseQuery(data)
+		if err != nil {
+			return nil, nil, NewInvalidCommandError(err.Error())
+		}
+		for key, value := range dataValues {
+			values[key] = append(values[key], value...)
+		}
+		data = ""
+	}
+
+	query, warnings := toQueryMap(values)
+
+	if c.user != "" {
+		c.headers["Authorization"] = types.String("Basic " + base64.StdEncoding.EncodeToString([]byte(c.user)))
+	}
+
+	doFile := &types.DoFile{
+		Do: types.Do{
+			Method:  types.String(c.requestMethod(data)),
+			URL:     types.String(parsedURL.String()),
+			Query:   query,
+			Headers: c.headers,
+		},
+	}
+
+	if len(c.form) > 0 {
+		if data != "" {
+			warnings = append(warnings, "data was ignored because the request has a form")
+		}
+		doFile.Do.Body = c.form
+	} else if data != "" {
+		if !hasHeader(c.headers, "Content-Type") {
+			c.headers["Content-Type"] = types.String("application/x-www-form-urlencoded")
+		}
+		doFile.Do.Body = types.String(data)
+	}
+
+	return doFile, warnings, nil
+}
+
+func (c *curlCommand) requestMethod(data string) string {
+	switch {
+	case c.method != "":
+		return c.method
+	case c.head:
+		return "HEAD"
+	case data != "" || len(c.form) > 0:
+		return "POST"
+	}
+
+	return "GET"
+}
+
+// toQueryMap returns the query values as a map, keeping the last value of the repeated keys
+func toQueryMap(values url.Values) (types.Map, []string) {
+	query := types.Map{}
+	warnings := make([]string, 0)
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if len(values[key]) > 1 {
+			warnings = append(warnings, "query param "+key+" is repeated, only the last value was kept")
+		}
+		query[key] = types.String(values[key][len(values[key])-1])
+	}
+
+	return query, warnings
+}
+
+func hasHeader(headers types.Map, name string) bool {
+	for key := range headers {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// urlEncode encodes the value as curl does for --data-urlencode
+func urlEncode(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
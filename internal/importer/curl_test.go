@@ -0,0 +1,158 @@
+package importer_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jibaru/do/internal/importer"
+)
+
+func TestCurlImporter_Import(t *testing.T) {
+	testCases := []struct {
+		name          string
+		command       string
+		expected      *importer.Result
+		expectedError error
+	}{
+		{
+			name:    "success get with query and basic auth",
+			command: `curl 'https://api.example.com/users?page=2&q=john%20doe' -u admin:secret -H 'Accept: text/html, application/json' --compressed`,
+			expected: &importer.Result{
+				Files: []importer.File{
+					{
+						Path: "request.do",
+						Content: "do {\n" +
+							"    method = \"GET\";\n" +
+							"    url = \"https://api.example.com/users\";\n" +
+							"    query = {\n" +
+							"        \"page\": \"2\",\n" +
+							"        \"q\": \"john doe\"\n" +
+							"    };\n" +
+							"    headers = {\n" +
+							"        \"Accept\": \"text/html, application/json\",\n" +
+							"        \"Authorization\": \"Basic YWRtaW46c2VjcmV0\"\n" +
+							"    };\n" +
+							"}\n",
+					},
+				},
+				Warnings: []string{},
+			},
+		},
+		{
+			name: "success post with data and line continuations",
+			command: "curl -XPOST \\\n" +
+				"  --url \"http://localhost:8080/users\" \\\n" +
+				"  -H \"Content-Type: application/json\" \\\n" +
+				"  --data-raw $'{\"name\": \"John\\'s\"}' -sSL",
+			expected: &importer.Result{
+				Files: []importer.File{
+					{
+						Path: "request.do",
+						Content: "do {\n" +
+							"    method = \"POST\";\n" +
+							"    url = \"http://localhost:8080/users\";\n" +
+							"    headers = {\n" +
+							"        \"Content-Type\": \"application/json\"\n" +
+							"    };\n" +
+							"    body = `{\"name\": \"John's\"}`;\n" +
+							"}\n",
+					},
+				},
+				Warnings: []string{},
+			},
+		},
+		{
+			name:    "success urlencoded data",
+			command: `curl localhost:8080/login -d user=john --data-urlencode 'password=a b&c' -o out.txt`,
+			expected: &importer.Result{
+				Files: []importer.File{
+					{
+						Path: "request.do",
+						Content: "do {\n" +
+							"    method = \"POST\";\n" +
+							"    url = \"http://localhost:8080/login\";\n" +
+							"    headers = {\n" +
+							"        \"Content-Type\": \"application/x-www-form-urlencoded\"\n" +
+							"    };\n" +
+							"    body = \"user=john&password=a%20b%26c\";\n" +
+							"}\n",
+					},
+				},
+				Warnings: []string{"option -o was ignored"},
+			},
+		},
+		{
+			name:    "success get with data as query",
+			command: `curl -G http://localhost:8080/search -d q=go -d q=do`,
+			expected: &importer.Result{
+				Files: []importer.File{
+					{
+						Path: "request.do",
+						Content: "do {\n" +
+							"    method = \"GET\";\n" +
+							"    url = \"http://localhost:8080/search\";\n" +
+							"    query = {\n" +
+							"        \"q\": \"do\"\n" +
+							"    };\n" +
+							"}\n",
+					},
+				},
+				Warnings: []string{"query param q is repeated, only the last value was kept"},
+			},
+		},
+		{
+			name:    "success multipart form",
+			command: `curl http://localhost:8080/upload -F 'avatar=@/tmp/avatar.png;type=image/png' -F name=john`,
+			expected: &importer.Result{
+				Files: []importer.File{
+					{
+						Path: "request.do",
+						Content: "let {\n" +
+							"    avatarFile = file(\"/tmp/avatar.png\");\n" +
+							"}\n\n" +
+							"do {\n" +
+							"    method = \"POST\";\n" +
+							"    url = \"http://localhost:8080/upload\";\n" +
+							"    body = {\n" +
+							"        \"avatar\": avatarFile,\n" +
+							"        \"name\": \"john\"\n" +
+							"    };\n" +
+							"}\n",
+					},
+				},
+				Warnings: []string{},
+			},
+		},
+		{
+			name:          "error missing url",
+			command:       `curl -X GET`,
+			expectedError: errors.New("url not found"),
+		},
+		{
+			name:          "error unterminated quote",
+			command:       `curl 'http://localhost`,
+			expectedError: errors.New("invalid command: unterminated single quote"),
+		},
+	}
+
+	i := importer.NewCurl()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := i.Import([]byte(tc.command))
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("expected %#v, got %#v", tc.expected, result)
+			}
+		})
+	}
+}
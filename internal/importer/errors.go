@@ -0,0 +1,64 @@
+package importer
+
+type InvalidCommandError struct {
+	reason string
+}
+
+type MissingURLError struct{}
+
+func NewInvalidCommandError(reason string) error {
+	return InvalidCommandError{reason}
+}
+
+func NewMissingURLError() error {
+	return MissingURLError{}
+}
+
+func (e InvalidCommandError) Error() string {
+	return "invalid command: " + e.reason
+}
+
+func (e MissingURLError) Error() string {
+	return "url not found"
+}
+
+type InvalidFileError struct {
+	reason string
+}
+
+func NewInvalidFileError(reason string) error {
+	return InvalidFileError{reason}
+}
+
+func (e InvalidFileError) Error() string {
+	return "invalid file: " + e.reason
+}
+
+type RequestNotFoundError struct {
+	filename string
+	selector string
+}
+
+func NewRequestNotFoundError(filename, selector string) error {
+	return RequestNotFoundError{filename, selector}
+}
+
+func (e RequestNotFoundError) Error() string {
+	if e.selector == "" {
+		return "no requests found in " + e.filename
+	}
+
+	return "request " + e.selector + " not found in " + e.filename
+}
+
+type UnquotableValueError struct {
+	value string
+}
+
+func NewUnquotableValueError(value string) error {
+	return UnquotableValueError{value}
+}
+
+func (e UnquotableValueError) Error() string {
+	return "value " + e.value + " has double quotes and backticks, it can not be written in a .do file"
+}
@@ -0,0 +1,138 @@
+package importer
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/jibaru/do/internal/har"
+	"github.com/jibaru/do/internal/reader"
+	"github.com/jibaru/do/internal/types"
+)
+
+// harSkippedHeaders defines the headers that are set by the http client when sending the request
+var harSkippedHeaders = map[string]bool{
+	"host":              true,
+	"content-length":    true,
+	"connection":        true,
+	"accept-encoding":   true,
+	"transfer-encoding": true,
+}
+
+type harImporter struct{}
+
+// NewHAR returns an importer that converts each entry of a HAR archive into a .do file
+func NewHAR() Importer {
+	return &harImporter{}
+}
+
+func (i *harImporter) Import(content []byte) (*Result, error) {
+	archive, err := har.Parse(content)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Files: make([]File, 0), Warnings: make([]string, 0)}
+
+	for idx, entry := range archive.Log.Entries {
+		name := fmt.Sprintf("entry %d", idx+1)
+
+		parsedURL, err := url.Parse(entry.Request.URL)
+		if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
+			result.Warnings = append(result.Warnings, name+": "+entry.Request.URL+" is not an http request and was not imported")
+			continue
+		}
+
+		filename := fmt.Sprintf("%03d_%s", idx+1, slug(entry.Request.Method+" "+parsedURL.Path))
+		filename = uniquePath(result.Files, filename, reader.DoFileExtension)
+
+		doFile, warnings := harDoFile(entry.Request, parsedURL)
+		for _, warning := range warnings {
+			result.Warnings = append(result.Warnings, name+": "+warning)
+		}
+
+		content, err := render(*doFile)
+		if err != nil {
+			result.Warnings = append(result.Warnings, name+": "+err.Error()+", the entry was not imported")
+			continue
+		}
+
+		result.Files = append(result.Files, File{Path: filename, Content: content})
+	}
+
+	return result, nil
+}
+
+// harDoFile returns the do file that sends the request of an entry
+func harDoFile(request har.Request, parsedURL *url.URL) (*types.DoFile, []string) {
+	values := parsedURL.Query()
+	parsedURL.RawQuery = ""
+	parsedURL.Fragment = ""
+
+	query, warnings := toQueryMap(values)
+
+	headers := types.Map{}
+	for _, header := range request.Headers {
+		if strings.HasPrefix(header.Name, ":") || harSkippedHeaders[strings.ToLower(header.Name)] {
+			continue
+		}
+		if _, ok := headers[header.Name]; ok {
+			warnings = append(warnings, "header "+header.Name+" is repeated, only the last value was kept")
+		}
+		headers[header.Name] = types.String(header.Value)
+	}
+
+	doFile := &types.DoFile{
+		Do: types.Do{
+			Method:  types.String(strings.ToUpper(request.Method)),
+			URL:     types.String(parsedURL.String()),
+			Query:   query,
+			Headers: headers,
+		},
+	}
+
+	postData := request.PostData
+	if postData == nil {
+		return doFile, warnings
+	}
+
+	if strings.HasPrefix(postData.MimeType, "multipart/form-data") && len(postData.Params) > 0 {
+		form := types.Map{}
+		for _, param := range postData.Params {
+			if param.FileName != "" {
+				form[param.Name] = types.File{Path: param.FileName}
+				warnings = append(warnings, "form file "+param.FileName+" was not captured, set its path before sending the request")
+				continue
+			}
+			form[param.Name] = types.String(param.Value)
+		}
+
+		// the boundary of the captured request is not valid for the new body
+		for key := range headers {
+			if strings.EqualFold(key, "Content-Type") {
+				delete(headers, key)
+			}
+		}
+
+		doFile.Do.Body = form
+		return doFile, warnings
+	}
+
+	body := postData.Text
+	if body == "" && len(postData.Params) > 0 {
+		parts := make([]string, 0, len(postData.Params))
+		for _, param := range postData.Params {
+			parts = append(parts, urlEncode(param.Name)+"="+urlEncode(param.Value))
+		}
+		body = strings.Join(parts, "&")
+	}
+
+	if body != "" {
+		if !hasHeader(headers, "Content-Type") && postData.MimeType != "" {
+			headers["Content-Type"] = types.String(postData.MimeType)
+		}
+		doFile.Do.Body = types.String(body)
+	}
+
+	return doFile, warnings
+}
@@ -0,0 +1,103 @@
+package importer_test
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/jibaru/do/internal/importer"
+)
+
+func TestHARImporter_Import(t *testing.T) {
+	testCases := []struct {
+		name          string
+		filename      string
+		content       string
+		expected      *importer.Result
+		expectedError error
+	}{
+		{
+			name:     "success browser capture",
+			filename: "testdata/capture.har",
+			expected: &importer.Result{
+				Files: []importer.File{
+					{
+						Path: "001_get_v1_users.do",
+						Content: "do {\n" +
+							"    method = \"GET\";\n" +
+							"    url = \"https://api.example.com/v1/users\";\n" +
+							"    query = {\n" +
+							"        \"page\": \"2\",\n" +
+							"        \"sort\": \"name\"\n" +
+							"    };\n" +
+							"    headers = {\n" +
+							"        \"accept\": \"application/json\",\n" +
+							"        \"cookie\": \"session=abc\"\n" +
+							"    };\n" +
+							"}\n",
+					},
+					{
+						Path: "003_post_v1_login.do",
+						Content: "do {\n" +
+							"    method = \"POST\";\n" +
+							"    url = \"https://api.example.com/v1/login\";\n" +
+							"    headers = {\n" +
+							"        \"Content-Type\": \"application/x-www-form-urlencoded\"\n" +
+							"    };\n" +
+							"    body = \"user=john&password=a%20b%26c\";\n" +
+							"}\n",
+					},
+					{
+						Path: "004_post_v1_avatar.do",
+						Content: "let {\n" +
+							"    avatarFile = file(\"avatar.png\");\n" +
+							"}\n\n" +
+							"do {\n" +
+							"    method = \"POST\";\n" +
+							"    url = \"https://api.example.com/v1/avatar\";\n" +
+							"    body = {\n" +
+							"        \"avatar\": avatarFile,\n" +
+							"        \"name\": \"john\"\n" +
+							"    };\n" +
+							"}\n",
+					},
+				},
+				Warnings: []string{
+					"entry 2: data:image/png;base64,iVBORw0KGgo= is not an http request and was not imported",
+					"entry 4: form file avatar.png was not captured, set its path before sending the request",
+				},
+			},
+		},
+		{
+			name:          "error not an archive",
+			content:       `{"info": {}}`,
+			expectedError: errors.New("invalid har archive: log entries not found"),
+		},
+	}
+
+	i := importer.NewHAR()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content := []byte(tc.content)
+			if tc.filename != "" {
+				content, _ = os.ReadFile(tc.filename)
+			}
+
+			result, err := i.Import(content)
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("expected %#v, got %#v", tc.expected, result)
+			}
+		})
+	}
+}
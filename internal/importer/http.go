@@ -0,0 +1,358 @@
+package importer
+
+import (
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jibaru/do/internal/reader"
+	"github.com/jibaru/do/internal/types"
+)
+
+var (
+	// httpFileExtensions defines the extensions of the files written in the .http format
+	httpFileExtensions = map[string]bool{".http": true, ".rest": true}
+
+	// httpRequestLine matches the method, url and optional version of a request line
+	httpRequestLine = regexp.MustCompile(`^(GET|POST|PUT|PATCH|DELETE|HEAD|OPTIONS|TRACE|CONNECT)\s+(\S+)(\s+HTTP/[\d.]+)?$`)
+
+	// httpVariable matches the @name = value declarations
+	httpVariable = regexp.MustCompile(`^@([\w.-]+)\s*=\s*(.*)$`)
+
+	// httpRequestName matches the # @name login comments
+	httpRequestName = regexp.MustCompile(`^(#|//)\s*@name\s*=?\s*(\S+)`)
+
+	// httpHeader matches the name: value headers
+	httpHeader = regexp.MustCompile(`^([^:\s]+)\s*:\s*(.*)$`)
+
+	// httpDynamicVariables defines the system variables that have an equivalent function
+	httpDynamicVariables = map[string]types.Func{
+		"$guid":              {Name: types.UuidFuncName},
+		"$uuid":              {Name: types.UuidFuncName},
+		"$random.uuid":       {Name: types.UuidFuncName},
+		"$randomUUID":        {Name: types.UuidFuncName},
+		"$isoTimestamp":      {Name: types.DateFuncName, Args: []interface{}{types.String("ISO8601")}},
+		"$datetime iso8601":  {Name: types.DateFuncName, Args: []interface{}{types.String("ISO8601")}},
+		"$datetime ISO8601":  {Name: types.DateFuncName, Args: []interface{}{types.String("ISO8601")}},
+		"$localDatetime iso": {Name: types.DateFuncName, Args: []interface{}{types.String("ISO8601")}},
+	}
+)
+
+// httpRequest defines a request of a .http file, before converting its variables
+type httpRequest struct {
+	name     string
+	method   string
+	url      string
+	headers  [][2]string
+	body     []string
+	warnings []string
+}
+
+// httpFile defines the requests and the file variables of a .http file
+type httpFile struct {
+	requests  []*httpRequest
+	variables map[string]string
+}
+
+type httpImporter struct{}
+
+// NewHTTP returns an importer that converts each request of a .http file, as written for the
+// VS Code REST Client or the JetBrains HTTP Client, into a .do file
+func NewHTTP() Importer {
+	return &httpImporter{}
+}
+
+func (i *httpImporter) Import(content []byte) (*Result, error) {
+	file := parseHTTPFile(string(content))
+	if len(file.requests) == 0 {
+		return nil, NewInvalidFileError("no requests found")
+	}
+
+	result := &Result{Files: make([]File, 0), Warnings: make([]string, 0)}
+
+	for idx, request := range file.requests {
+		name := request.name
+		if name == "" {
+			name = "request " + strconv.Itoa(idx+1)
+		}
+		filename := uniquePath(result.Files, slug(splitCamelCase(name)), reader.DoFileExtension)
+
+		doFile, warnings := file.toDoFile(request)
+		for _, warning := range warnings {
+			result.Warnings = append(result.Warnings, "request "+filename+": "+warning)
+		}
+
+		content, err := render(doFile)
+		if err != nil {
+			result.Warnings = append(result.Warnings, "request "+filename+": "+err.Error()+", the request was not imported")
+			continue
+		}
+
+		result.Files = append(result.Files, File{Path: filename, Content: content})
+	}
+
+	return result, nil
+}
+
+type httpFileReader struct {
+	next reader.FileReader
+}
+
+// NewHTTPFileReader returns a reader that converts .http and .rest files into the content of a .do
+// file, reading any other file with next. The request is selected by appending #name or #number
+// to the filename, the first one is used by default.
+func NewHTTPFileReader(next reader.FileReader) reader.FileReader {
+	return &httpFileReader{next}
+}
+
+func (r *httpFileReader) Read(filename string) (types.FileReaderContent, error) {
+	path, selector := filename, ""
+	if idx := strings.LastIndex(filename, "#"); idx != -1 && httpFileExtensions[filepath.Ext(filename[:idx])] {
+		path, selector = filename[:idx], filename[idx+1:]
+	}
+
+	if !httpFileExtensions[filepath.Ext(path)] {
+		return r.next.Read(filename)
+	}
+
+	content, err := r.next.Read(path)
+	if err != nil {
+		return "", err
+	}
+
+	file := parseHTTPFile(string(content))
+	for idx, request := range file.requests {
+		if selector == "" || selector == request.name || selector == strconv.Itoa(idx+1) {
+			doFile, _ := file.toDoFile(request)
+			content, err := render(doFile)
+			return types.FileReaderContent(content), err
+		}
+	}
+
+	return "", NewRequestNotFoundError(path, selector)
+}
+
+// parseHTTPFile returns the requests of the content, separated by ### lines
+func parseHTTPFile(content string) *httpFile {
+	file := &httpFile{requests: make([]*httpRequest, 0), variables: map[string]string{}}
+
+	var request *httpRequest
+	title := ""
+	inBody := false
+
+	finish := func() {
+		if request != nil {
+			request.finish()
+			file.requests = append(file.requests, request)
+		}
+		request, inBody = nil, false
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "###"):
+			finish()
+			title = strings.TrimSpace(strings.TrimLeft(trimmed, "#"))
+		case inBody:
+			request.body = append(request.body, line)
+		case request == nil:
+			if match := httpVariable.FindStringSubmatch(trimmed); match != nil {
+				file.variables[match[1]] = strings.TrimSpace(match[2])
+			} else if match := httpRequestName.FindStringSubmatch(trimmed); match != nil {
+				title = match[2]
+			} else if trimmed != "" && !isHTTPComment(trimmed) {
+				request = newHTTPRequest(title, trimmed)
+				title = ""
+			}
+		case trimmed == "":
+			inBody = true
+		case isHTTPComment(trimmed):
+		case len(request.headers) == 0 && (strings.HasPrefix(trimmed, "?") || strings.HasPrefix(trimmed, "&")):
+			// the query can continue in the next lines
+			request.url += trimmed
+		default:
+			if match := httpHeader.FindStringSubmatch(trimmed); match != nil {
+				request.headers = append(request.headers, [2]string{match[1], strings.TrimSpace(match[2])})
+			} else {
+				request.warnings = append(request.warnings, "invalid header "+trimmed+" was ignored")
+			}
+		}
+	}
+
+	finish()
+
+	return file
+}
+
+func newHTTPRequest(name, line string) *httpRequest {
+	request := &httpRequest{name: name, method: "GET", url: line, warnings: make([]string, 0)}
+
+	if match := httpRequestLine.FindStringSubmatch(line); match != nil {
+		request.method, request.url = match[1], match[2]
+	} else if fields := strings.Fields(line); len(fields) > 1 && strings.HasPrefix(fields[len(fields)-1], "HTTP/") {
+		request.url = strings.Join(fields[:len(fields)-1], " ")
+	}
+
+	return request
+}
+
+// finish removes from the body the parts that are not sent, like response handlers
+func (r *httpRequest) finish() {
+	body := make([]string, 0, len(r.body))
+
+	for idx, line := range r.body {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "> ") || strings.HasPrefix(trimmed, ">> ") || strings.HasPrefix(trimmed, ">>! ") {
+			r.warnings = append(r.warnings, "response handler was not converted")
+			break
+		}
+
+		if strings.HasPrefix(trimmed, "<> ") {
+			continue
+		}
+
+		if idx == 0 && strings.HasPrefix(trimmed, "< ") {
+			r.warnings = append(r.warnings, "body from file "+strings.TrimSpace(trimmed[2:])+" was not converted")
+			body = nil
+			break
+		}
+
+		body = append(body, line)
+	}
+
+	r.body = strings.Split(strings.Trim(strings.Join(body, "\n"), "\n"), "\n")
+}
+
+// toDoFile returns the do file of the request, declaring in the let section the variables it uses
+func (f *httpFile) toDoFile(request *httpRequest) (types.DoFile, []string) {
+	let := types.Map{}
+	warnings := append(make([]string, 0), request.warnings...)
+	warn := func(message string) {
+		for _, warning := range warnings {
+			if warning == message {
+				return
+			}
+		}
+		warnings = append(warnings, message)
+	}
+
+	declare := func(reference string) (string, bool) {
+		name := variableName(reference)
+
+		if value, ok := f.variables[reference]; ok {
+			expanded := f.expand(value, map[string]bool{reference: true})
+			if match := template.FindStringSubmatch(expanded); match != nil {
+				warn("variable " + reference + " uses " + strings.TrimSpace(match[1]) + ", which is not declared in the file")
+			}
+			let[name] = types.String(expanded)
+			return name, true
+		}
+
+		if strings.Contains(reference, ".response.") || strings.Contains(reference, ".request.") {
+			warn("reference to another request " + reference + " was not converted")
+			return "", false
+		}
+
+		for _, prefix := range []string{"$processEnv ", "$dotenv "} {
+			if strings.HasPrefix(reference, prefix) {
+				envName := strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(reference, prefix)), "%")
+				name = variableName(envName)
+				let[name] = types.Func{Name: types.EnvFuncName, Args: []interface{}{types.String(envName)}}
+				return name, true
+			}
+		}
+
+		if strings.HasPrefix(reference, "$") {
+			fn, ok := httpDynamicVariables[reference]
+			if !ok {
+				warn("system variable " + reference + " was not converted")
+				return "", false
+			}
+			let[name] = fn
+			return name, true
+		}
+
+		if _, ok := let[name]; !ok {
+			let[name] = types.Func{Name: types.EnvFuncName, Args: []interface{}{types.String(name)}}
+		}
+
+		return name, true
+	}
+
+	headers := types.Map{}
+	for _, header := range request.headers {
+		headers[header[0]] = types.String(replaceTemplates(header[1], nil, declare))
+	}
+
+	rawURL, query := request.url, types.Map{}
+	if idx := strings.Index(rawURL, "#"); idx != -1 && !strings.Contains(rawURL[idx:], "}}") {
+		rawURL = rawURL[:idx]
+	}
+	if idx := strings.Index(rawURL, "?"); idx != -1 {
+		values, err := url.ParseQuery(rawURL[idx+1:])
+		if err == nil {
+			rawURL = rawURL[:idx]
+			var queryWarnings []string
+			query, queryWarnings = toQueryMap(values)
+			for _, warning := range queryWarnings {
+				warn(warning)
+			}
+			for key, value := range query {
+				query[key] = types.String(replaceTemplates(string(value.(types.String)), nil, declare))
+			}
+		}
+	}
+
+	if strings.HasPrefix(rawURL, "/") {
+		for key, value := range headers {
+			if strings.EqualFold(key, "Host") {
+				rawURL = "http://" + string(value.(types.String)) + rawURL
+				delete(headers, key)
+			}
+		}
+	}
+
+	doFile := types.DoFile{
+		Do: types.Do{
+			Method:  types.String(request.method),
+			URL:     types.String(replaceTemplates(rawURL, nil, declare)),
+			Query:   query,
+			Headers: headers,
+		},
+	}
+
+	if body := strings.Join(request.body, "\n"); body != "" {
+		doFile.Do.Body = types.String(replaceTemplates(body, nil, declare))
+	}
+
+	if len(let) > 0 {
+		doFile.Let.Variables = let
+	}
+
+	return doFile, warnings
+}
+
+// expand returns the value of a file variable with the file variables it references inlined
+func (f *httpFile) expand(value string, seen map[string]bool) string {
+	return template.ReplaceAllStringFunc(value, func(match string) string {
+		reference := strings.TrimSpace(match[2 : len(match)-2])
+		nested, ok := f.variables[reference]
+		if !ok || seen[reference] {
+			return match
+		}
+
+		seen[reference] = true
+		defer delete(seen, reference)
+
+		return f.expand(nested, seen)
+	})
+}
+
+func isHTTPComment(line string) bool {
+	return strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//")
+}
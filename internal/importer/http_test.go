@@ -0,0 +1,207 @@
+package importer_test
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/jibaru/do/internal/importer"
+	"github.com/jibaru/do/internal/reader"
+	"github.com/jibaru/do/internal/types"
+)
+
+const (
+	listUsersDoFile = "let {\n" +
+		"    baseUrl = \"http://localhost:8080/api\";\n" +
+		"    contentType = \"application/json\";\n" +
+		"    token = env(\"token\");\n" +
+		"}\n\n" +
+		"do {\n" +
+		"    method = \"GET\";\n" +
+		"    url = \"$baseUrl/users\";\n" +
+		"    query = {\n" +
+		"        \"page\": \"2\",\n" +
+		"        \"q\": \"john doe\"\n" +
+		"    };\n" +
+		"    headers = {\n" +
+		"        \"Accept\": \"$contentType\",\n" +
+		"        \"Authorization\": \"Bearer $token\"\n" +
+		"    };\n" +
+		"}\n"
+
+	createUserDoFile = "let {\n" +
+		"    baseUrl = \"http://localhost:8080/api\";\n" +
+		"    contentType = \"application/json\";\n" +
+		"    datetime_iso8601 = date(\"ISO8601\");\n" +
+		"    guid = uuid();\n" +
+		"}\n\n" +
+		"do {\n" +
+		"    method = \"POST\";\n" +
+		"    url = \"$baseUrl/users\";\n" +
+		"    headers = {\n" +
+		"        \"Content-Type\": \"$contentType\",\n" +
+		"        \"X-Request-Id\": \"$guid\"\n" +
+		"    };\n" +
+		"    body = `{\n" +
+		"    \"name\": \"John\",\n" +
+		"    \"createdAt\": \"$datetime_iso8601\"\n" +
+		"}`;\n" +
+		"}\n"
+)
+
+func TestHTTPImporter_Import(t *testing.T) {
+	testCases := []struct {
+		name          string
+		filename      string
+		content       string
+		expected      *importer.Result
+		expectedError error
+	}{
+		{
+			name:     "success",
+			filename: "testdata/api.http",
+			expected: &importer.Result{
+				Files: []importer.File{
+					{Path: "list_users.do", Content: listUsersDoFile},
+					{Path: "create_user.do", Content: createUserDoFile},
+					{
+						Path: "get_user.do",
+						Content: "let {\n" +
+							"    host = \"localhost:8080\";\n" +
+							"}\n\n" +
+							"do {\n" +
+							"    method = \"GET\";\n" +
+							"    url = \"http://$host/api/users/{{createUser.response.body.$.id}}\";\n" +
+							"}\n",
+					},
+					{
+						Path: "request_4.do",
+						Content: "let {\n" +
+							"    baseUrl = \"http://localhost:8080/api\";\n" +
+							"}\n\n" +
+							"do {\n" +
+							"    method = \"POST\";\n" +
+							"    url = \"$baseUrl/avatar\";\n" +
+							"    headers = {\n" +
+							"        \"Content-Type\": \"application/octet-stream\"\n" +
+							"    };\n" +
+							"}\n",
+					},
+				},
+				Warnings: []string{
+					"request create_user.do: response handler was not converted",
+					"request get_user.do: reference to another request createUser.response.body.$.id was not converted",
+					"request request_4.do: body from file ./avatar.png was not converted",
+				},
+			},
+		},
+		{
+			name:    "success request with double quotes and backticks not imported",
+			content: "GET http://localhost/users\n\n###\n\nPOST http://localhost/users\n\n{\"name\": \"a`b\"}\n",
+			expected: &importer.Result{
+				Files: []importer.File{
+					{Path: "request_1.do", Content: "do {\n    method = \"GET\";\n    url = \"http://localhost/users\";\n}\n"},
+				},
+				Warnings: []string{
+					"request request_2.do: value {\"name\": \"a`b\"} has double quotes and backticks, it can not be written in a .do file, the request was not imported",
+				},
+			},
+		},
+		{
+			name:          "error without requests",
+			content:       "@host = localhost\n# only comments\n",
+			expectedError: errors.New("invalid file: no requests found"),
+		},
+	}
+
+	i := importer.NewHTTP()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content := []byte(tc.content)
+			if tc.filename != "" {
+				content, _ = os.ReadFile(tc.filename)
+			}
+
+			result, err := i.Import(content)
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("expected %#v, got %#v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestHTTPFileReader_Read(t *testing.T) {
+	testCases := []struct {
+		name          string
+		filename      string
+		expected      types.FileReaderContent
+		expectedError error
+	}{
+		{
+			name:     "success do file",
+			filename: "testdata/request.do",
+			expected: "do file",
+		},
+		{
+			name:     "success first request",
+			filename: "testdata/api.http",
+			expected: listUsersDoFile,
+		},
+		{
+			name:     "success request by name",
+			filename: "testdata/api.http#createUser",
+			expected: createUserDoFile,
+		},
+		{
+			name:     "success request by number",
+			filename: "testdata/api.http#2",
+			expected: createUserDoFile,
+		},
+		{
+			name:          "error request not found",
+			filename:      "testdata/api.http#login",
+			expectedError: errors.New("request login not found in testdata/api.http"),
+		},
+	}
+
+	next := &reader.Mock{
+		ReadFn: func(filename string) (types.FileReaderContent, error) {
+			if filename == "testdata/request.do" {
+				return "do file", nil
+			}
+
+			return reader.NewFileReader().Read(filename)
+		},
+	}
+
+	r := importer.NewHTTPFileReader(next)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content, err := r.Read(tc.filename)
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if content != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, content)
+			}
+		})
+	}
+}
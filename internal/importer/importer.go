@@ -0,0 +1,244 @@
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+const indentation = "    "
+
+var (
+	// template matches the {{variable}} references used by other tools
+	template = regexp.MustCompile(`\{\{([^{}]+)\}\}`)
+
+	// camelCase matches the boundaries between the words of a camel case name
+	camelCase = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+)
+
+// File defines a file generated by an import
+type File struct {
+	Path    string
+	Content string
+}
+
+// Result defines the files generated by an import and the warnings about the
+// content that could not be converted
+type Result struct {
+	Files    []File
+	Warnings []string
+}
+
+type Importer interface {
+	// Import converts the content from another format into .do files.
+	Import(content []byte) (*Result, error)
+}
+
+// render returns the content of the .do file that describes the do file, or an error when
+// a value can not be written in a .do file
+func render(doFile types.DoFile) (string, error) {
+	content := strings.Builder{}
+	doFile = declareFiles(doFile)
+
+	// field writes a field of a section, ending it with a semicolon
+	field := func(key string, value interface{}) error {
+		rendered, err := renderValue(value, 1)
+		if err != nil {
+			return err
+		}
+		content.WriteString(indentation + key + " = " + rendered + ";\n")
+		return nil
+	}
+
+	if len(doFile.Let.Variables) > 0 {
+		content.WriteString("let {\n")
+		for _, key := range sortedKeys(doFile.Let.Variables) {
+			if err := field(key, doFile.Let.Variables[key]); err != nil {
+				return "", err
+			}
+		}
+		content.WriteString("}\n\n")
+	}
+
+	content.WriteString("do {\n")
+	if err := field(types.DoMethod, doFile.Do.Method); err != nil {
+		return "", err
+	}
+	if err := field(types.DoURL, doFile.Do.URL); err != nil {
+		return "", err
+	}
+
+	fields := []struct {
+		key   string
+		value types.Map
+	}{
+		{types.DoParams, doFile.Do.Params},
+		{types.DoQuery, doFile.Do.Query},
+		{types.DoHeaders, doFile.Do.Headers},
+	}
+	for _, f := range fields {
+		if len(f.value) > 0 {
+			if err := field(f.key, f.value); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	switch body := doFile.Do.Body.(type) {
+	case types.String:
+		if body != "" {
+			if err := field(types.DoBody, body); err != nil {
+				return "", err
+			}
+		}
+	case types.Map:
+		if len(body) > 0 {
+			if err := field(types.DoBody, body); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	content.WriteString("}\n")
+
+	return content.String(), nil
+}
+
+// declareFiles moves the files of a multipart body to the let section, as functions
+// can only be called there
+func declareFiles(doFile types.DoFile) types.DoFile {
+	body, ok := doFile.Do.Body.(types.Map)
+	if !ok {
+		return doFile
+	}
+
+	variables := map[string]interface{}{}
+	for key, value := range doFile.Let.Variables {
+		variables[key] = value
+	}
+
+	form := types.Map{}
+	for _, key := range sortedKeys(body) {
+		file, ok := body[key].(types.File)
+		if !ok {
+			form[key] = body[key]
+			continue
+		}
+
+		name := variableName(key + "File")
+		for n := 2; variables[name] != nil; n++ {
+			name = variableName(key+"File") + strconv.Itoa(n)
+		}
+
+		variables[name] = types.Func{Name: types.FileFuncName, Args: []interface{}{types.String(file.Path)}}
+		form[key] = types.NewReferenceToVariable(name)
+	}
+
+	doFile.Let.Variables = variables
+	doFile.Do.Body = form
+
+	return doFile
+}
+
+// replaceTemplates replaces the {{variable}} references with $variable, encoding the text around them.
+// The declare function returns the let variable of a reference, or false to keep the reference as it is.
+func replaceTemplates(text string, encode func(string) string, declare func(reference string) (string, bool)) string {
+	if encode == nil {
+		encode = func(value string) string { return value }
+	}
+
+	result := strings.Builder{}
+	last := 0
+
+	for _, match := range template.FindAllStringSubmatchIndex(text, -1) {
+		result.WriteString(encode(text[last:match[0]]))
+		last = match[1]
+
+		name, ok := declare(strings.TrimSpace(text[match[2]:match[3]]))
+		if !ok {
+			result.WriteString(text[match[0]:match[1]])
+			continue
+		}
+
+		result.WriteString("$" + name)
+	}
+
+	result.WriteString(encode(text[last:]))
+
+	return result.String()
+}
+
+// renderValue returns the value as it is written in a .do file
+func renderValue(value interface{}, depth int) (string, error) {
+	switch val := value.(type) {
+	case types.String:
+		return quote(string(val))
+	case types.File:
+		path, err := quote(val.Path)
+		if err != nil {
+			return "", err
+		}
+		return types.FileFuncName + "(" + path + ")", nil
+	case types.ReferenceToVariable:
+		return val.Value, nil
+	case types.Func:
+		args := make([]string, 0, len(val.Args))
+		for _, arg := range val.Args {
+			rendered, err := renderValue(arg, depth)
+			if err != nil {
+				return "", err
+			}
+			args = append(args, rendered)
+		}
+		return val.Name + "(" + strings.Join(args, ", ") + ")", nil
+	case types.Map:
+		entries := make([]string, 0, len(val))
+		for _, key := range sortedKeys(val) {
+			quotedKey, err := quote(key)
+			if err != nil {
+				return "", err
+			}
+			rendered, err := renderValue(val[key], depth+1)
+			if err != nil {
+				return "", err
+			}
+			entries = append(entries, strings.Repeat(indentation, depth+1)+quotedKey+": "+rendered)
+		}
+		return "{\n" + strings.Join(entries, ",\n") + "\n" + strings.Repeat(indentation, depth) + "}", nil
+	}
+
+	return fmt.Sprintf("%v", value), nil
+}
+
+// quote wraps the value in double quotes, or in backticks when it contains double quotes.
+// A .do file has no escapes, so a value with both can not be written.
+func quote(value string) (string, error) {
+	hasQuotes, hasBackticks := strings.Contains(value, `"`), strings.Contains(value, "`")
+	switch {
+	case hasQuotes && hasBackticks:
+		return "", NewUnquotableValueError(value)
+	case hasQuotes:
+		return "`" + value + "`", nil
+	}
+
+	return `"` + value + `"`, nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// splitCamelCase separates the words of a camel case name with spaces
+func splitCamelCase(name string) string {
+	return camelCase.ReplaceAllString(name, "${1} ${2}")
+}
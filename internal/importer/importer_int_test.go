@@ -0,0 +1,154 @@
+package importer_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jibaru/do/internal/importer"
+	"github.com/jibaru/do/internal/parser"
+	"github.com/jibaru/do/internal/parser/analyzer"
+	"github.com/jibaru/do/internal/parser/caller"
+	"github.com/jibaru/do/internal/parser/cleaner"
+	"github.com/jibaru/do/internal/parser/extractor"
+	"github.com/jibaru/do/internal/parser/normalizer"
+	"github.com/jibaru/do/internal/parser/partitioner"
+	"github.com/jibaru/do/internal/parser/replacer"
+	"github.com/jibaru/do/internal/parser/resolver"
+	"github.com/jibaru/do/internal/parser/taker"
+	"github.com/jibaru/do/internal/reader"
+	"github.com/jibaru/do/internal/types"
+	"github.com/jibaru/do/internal/utils"
+)
+
+func TestImport_Parse_Integration(t *testing.T) {
+	testCases := []struct {
+		name            string
+		importer        importer.Importer
+		content         string
+		expectedHeaders types.Map
+		expectedBody    interface{}
+		expectedError   error
+	}{
+		{
+			name:         "success curl body with double quotes",
+			importer:     importer.NewCurl(),
+			content:      `curl -X POST http://localhost/users -d '{"name": "a;b", "c": "{d}"}'`,
+			expectedBody: types.String(`{"name":"a;b","c":"{d}"}`),
+		},
+		{
+			name:            "success curl header with backticks",
+			importer:        importer.NewCurl(),
+			content:         "curl http://localhost/users -H 'X-Name: a`b'",
+			expectedHeaders: types.Map{"X-Name": types.String("a`b")},
+		},
+		{
+			name:          "error curl body with double quotes and backticks",
+			importer:      importer.NewCurl(),
+			content:       "curl -X POST http://localhost/users -d '{\"name\": \"a`b\", \"c\": \"d;e\"}'",
+			expectedError: errors.New("value {\"name\": \"a`b\", \"c\": \"d;e\"} has double quotes and backticks, it can not be written in a .do file"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.importer.Import([]byte(tc.content))
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if result == nil {
+				return
+			}
+
+			doFile, err := newParser(&reader.Mock{
+				ReadFn: func(filename string) (types.FileReaderContent, error) {
+					return types.FileReaderContent(result.Files[0].Content), nil
+				},
+			}).ParseFromFilename(result.Files[0].Path)
+			if err != nil {
+				t.Fatalf("expected no error parsing %s, got %v", result.Files[0].Content, err)
+			}
+
+			assertDo(t, doFile.Do, tc.expectedHeaders, tc.expectedBody)
+		})
+	}
+}
+
+func TestHTTPFileReader_Parse_Integration(t *testing.T) {
+	testCases := []struct {
+		name            string
+		content         string
+		expectedHeaders types.Map
+		expectedBody    interface{}
+		expectedError   error
+	}{
+		{
+			name:            "success body with double quotes",
+			content:         "POST http://localhost/users\nContent-Type: application/json\n\n{\"name\": \"a;b\"}\n",
+			expectedHeaders: types.Map{"Content-Type": types.String("application/json")},
+			expectedBody:    types.String(`{"name":"a;b"}`),
+		},
+		{
+			name:          "error body with double quotes and backticks",
+			content:       "POST http://localhost/users\n\n{\"name\": \"a`b\", \"c\": \"d;e\"}\n",
+			expectedError: errors.New("value {\"name\": \"a`b\", \"c\": \"d;e\"} has double quotes and backticks, it can not be written in a .do file"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			theParser := newParser(importer.NewHTTPFileReader(&reader.Mock{
+				ReadFn: func(filename string) (types.FileReaderContent, error) {
+					return types.FileReaderContent(tc.content), nil
+				},
+			}))
+
+			doFile, err := theParser.ParseFromFilename("api.http")
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if doFile != nil {
+				assertDo(t, doFile.Do, tc.expectedHeaders, tc.expectedBody)
+			}
+		})
+	}
+}
+
+// newParser returns a parser with the components used by the command, reading the files with fileReader
+func newParser(fileReader reader.FileReader) parser.Parser {
+	uuidFactory := utils.NewFixedUuidFactory("80aaa8e2-e2b9-4bd5-8124-4003d4a528df")
+	dateFactory := utils.NewFixedDateFactory(time.Now())
+
+	sectionExtractor := extractor.New(taker.New(), normalizer.New(), partitioner.New(), analyzer.New())
+	funcCaller := caller.New(uuidFactory, dateFactory, nil)
+	letResolver := resolver.NewLetResolver(uuidFactory, dateFactory, nil)
+
+	return parser.New(fileReader, cleaner.New(), sectionExtractor, replacer.New(), funcCaller, letResolver)
+}
+
+// assertDo checks the headers and the body of the parsed request
+func assertDo(t *testing.T, do types.Do, expectedHeaders types.Map, expectedBody interface{}) {
+	t.Helper()
+
+	for key, value := range expectedHeaders {
+		if do.Headers[key] != value {
+			t.Errorf("expected header %s %q, got %q", key, value, do.Headers[key])
+		}
+	}
+
+	if expectedBody != nil && do.Body != expectedBody {
+		t.Errorf("expected body %q, got %q", expectedBody, do.Body)
+	}
+}
@@ -0,0 +1,9 @@
+package importer
+
+type Mock struct {
+	ImportFn func(content []byte) (*Result, error)
+}
+
+func (m *Mock) Import(content []byte) (*Result, error) {
+	return m.ImportFn(content)
+}
@@ -0,0 +1,392 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/jibaru/do/internal/reader"
+	"github.com/jibaru/do/internal/types"
+)
+
+const (
+	// openAPIBaseURLVariable defines the let variable that holds the server url in every file
+	openAPIBaseURLVariable = "baseUrl"
+	// openAPIBaseURLEnv defines the environment variable that overrides the server url
+	openAPIBaseURLEnv = "BASE_URL"
+	// openAPIMaxDepth defines how deep the examples are generated from recursive schemas
+	openAPIMaxDepth = 8
+)
+
+var (
+	// openAPIMethods defines the operations of a path item in the order they are imported
+	openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+	// openAPIPathParam matches the {param} templates of a path
+	openAPIPathParam = regexp.MustCompile(`\{([^{}]+)\}`)
+
+	// openAPIStringFormats defines the example values of the string formats
+	openAPIStringFormats = map[string]string{
+		"date":      "2024-01-01",
+		"date-time": "2024-01-01T00:00:00Z",
+		"uuid":      "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+		"email":     "user@example.com",
+		"uri":       "https://example.com",
+		"hostname":  "example.com",
+		"ipv4":      "127.0.0.1",
+		"password":  "password",
+	}
+)
+
+type openAPIImporter struct{}
+
+// NewOpenAPI returns an importer that converts each operation of an OpenAPI 3 specification,
+// written in yaml or json, into a .do file
+func NewOpenAPI() Importer {
+	return &openAPIImporter{}
+}
+
+func (i *openAPIImporter) Import(content []byte) (*Result, error) {
+	var spec map[string]interface{}
+	if err := yaml.Unmarshal(content, &spec); err != nil {
+		return nil, NewInvalidFileError(err.Error())
+	}
+
+	if _, ok := spec["swagger"]; ok {
+		return nil, NewInvalidFileError("swagger 2.0 is not supported, convert it to OpenAPI 3 first")
+	}
+
+	version, _ := spec["openapi"].(string)
+	paths, ok := spec["paths"].(map[string]interface{})
+	if !strings.HasPrefix(version, "3.") || !ok {
+		return nil, NewInvalidFileError("not an OpenAPI 3 specification")
+	}
+
+	c := &openAPIConverter{spec: spec, warnings: make([]string, 0)}
+	baseURL := c.baseURL()
+
+	files := make([]File, 0)
+	for _, pathName := range sortedKeys(paths) {
+		pathItem := c.resolve(paths[pathName])
+
+		for _, method := range openAPIMethods {
+			operation := asMap(pathItem[method])
+			if operation == nil {
+				continue
+			}
+
+			name, _ := operation["operationId"].(string)
+			if name == "" {
+				name = method + " " + pathName
+			}
+			filename := uniquePath(files, slug(splitCamelCase(name)), reader.DoFileExtension)
+
+			c.name = "operation " + strings.ToUpper(method) + " " + pathName
+			doFile := c.toDoFile(method, pathName, pathItem, operation)
+			doFile.Let.Variables = map[string]interface{}{
+				openAPIBaseURLVariable: types.Func{
+					Name: types.EnvFuncName,
+					Args: []interface{}{types.String(openAPIBaseURLEnv), types.String(baseURL)},
+				},
+			}
+
+			content, err := render(doFile)
+			if err != nil {
+				c.warnings = append(c.warnings, c.name+": "+err.Error()+", the operation was not imported")
+				continue
+			}
+
+			files = append(files, File{Path: filename, Content: content})
+		}
+	}
+
+	return &Result{Files: files, Warnings: c.warnings}, nil
+}
+
+// openAPIConverter converts the operations of a specification, resolving its references
+type openAPIConverter struct {
+	spec     map[string]interface{}
+	name     string
+	warnings []string
+}
+
+// baseURL returns the url of the first server with the default values of its variables
+func (c *openAPIConverter) baseURL() string {
+	servers, _ := c.spec["servers"].([]interface{})
+	if len(servers) == 0 {
+		c.warnings = append(c.warnings, "the specification has no servers, http://localhost was used")
+		return "http://localhost"
+	}
+
+	server := asMap(servers[0])
+	serverURL, _ := server["url"].(string)
+	variables := asMap(server["variables"])
+	serverURL = openAPIPathParam.ReplaceAllStringFunc(serverURL, func(match string) string {
+		if value, ok := asMap(variables[match[1:len(match)-1]])["default"]; ok {
+			return fmt.Sprintf("%v", value)
+		}
+		return match
+	})
+
+	if !strings.Contains(serverURL, "://") {
+		c.warnings = append(c.warnings, "the server url "+serverURL+" is relative, set "+openAPIBaseURLEnv+" before sending the requests")
+	}
+
+	return strings.TrimSuffix(serverURL, "/")
+}
+
+func (c *openAPIConverter) toDoFile(method, pathName string, pathItem, operation map[string]interface{}) types.DoFile {
+	doFile := types.DoFile{
+		Do: types.Do{
+			Method:  types.String(strings.ToUpper(method)),
+			URL:     types.String("$" + openAPIBaseURLVariable + openAPIPathParam.ReplaceAllString(pathName, ":$1")),
+			Params:  types.Map{},
+			Query:   types.Map{},
+			Headers: types.Map{},
+		},
+	}
+
+	for _, parameter := range c.parameters(pathItem, operation) {
+		name, _ := parameter["name"].(string)
+		required, _ := parameter["required"].(bool)
+		value := types.String(fmt.Sprintf("%v", c.parameterExample(parameter)))
+
+		switch parameter["in"] {
+		case "path":
+			doFile.Do.Params[name] = value
+		case "query":
+			if required {
+				doFile.Do.Query[name] = value
+			}
+		case "header":
+			if required {
+				doFile.Do.Headers[name] = value
+			}
+		case "cookie":
+			if required {
+				c.warn("has the cookie parameter " + name + " and it was not converted")
+			}
+		}
+	}
+
+	c.setBody(&doFile, c.resolve(operation["requestBody"]))
+
+	return doFile
+}
+
+// parameters returns the parameters of the path item overridden by the ones of the operation
+func (c *openAPIConverter) parameters(pathItem, operation map[string]interface{}) []map[string]interface{} {
+	parameters := make([]map[string]interface{}, 0)
+	positions := map[string]int{}
+
+	for _, list := range []interface{}{pathItem["parameters"], operation["parameters"]} {
+		items, _ := list.([]interface{})
+		for _, item := range items {
+			parameter := c.resolve(item)
+			id := fmt.Sprintf("%v:%v", parameter["in"], parameter["name"])
+			if position, ok := positions[id]; ok {
+				parameters[position] = parameter
+				continue
+			}
+			positions[id] = len(parameters)
+			parameters = append(parameters, parameter)
+		}
+	}
+
+	return parameters
+}
+
+func (c *openAPIConverter) parameterExample(parameter map[string]interface{}) interface{} {
+	if example, ok := parameter["example"]; ok {
+		return example
+	}
+
+	if example, ok := c.firstExample(parameter["examples"]); ok {
+		return example
+	}
+
+	return c.example(parameter["schema"], 0)
+}
+
+func (c *openAPIConverter) setBody(doFile *types.DoFile, requestBody map[string]interface{}) {
+	content := asMap(requestBody["content"])
+	if len(content) == 0 {
+		return
+	}
+
+	mediaTypes := sortedKeys(content)
+	mediaType := mediaTypes[0]
+	for _, candidate := range mediaTypes {
+		if candidate == "application/json" || strings.HasSuffix(candidate, "+json") {
+			mediaType = candidate
+			break
+		}
+	}
+
+	media := c.resolve(content[mediaType])
+	example, ok := media["example"]
+	if !ok {
+		example, ok = c.firstExample(media["examples"])
+	}
+	if !ok {
+		example = c.example(media["schema"], 0)
+	}
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		body, err := json.Marshal(example)
+		if err != nil {
+			c.warn("has a " + mediaType + " body that can not be converted")
+			return
+		}
+		doFile.Do.Body = types.String(body)
+	case mediaType == "application/x-www-form-urlencoded":
+		values := url.Values{}
+		fields := asMap(example)
+		for _, key := range sortedKeys(fields) {
+			values.Set(key, fmt.Sprintf("%v", fields[key]))
+		}
+		doFile.Do.Body = types.String(values.Encode())
+	case mediaType == "multipart/form-data":
+		form := types.Map{}
+		properties := asMap(c.resolve(media["schema"])["properties"])
+		fields := asMap(example)
+		for _, key := range sortedKeys(fields) {
+			if asMap(c.resolve(properties[key]))["format"] == "binary" {
+				form[key] = types.File{Path: path.Join("path", "to", key)}
+				continue
+			}
+			form[key] = types.String(fmt.Sprintf("%v", fields[key]))
+		}
+		doFile.Do.Body = form
+		return
+	case strings.HasPrefix(mediaType, "text/"):
+		doFile.Do.Body = types.String(fmt.Sprintf("%v", example))
+	default:
+		c.warn("has a " + mediaType + " body and it was not converted")
+		return
+	}
+
+	doFile.Do.Headers["Content-Type"] = types.String(mediaType)
+}
+
+// example returns an example value for the schema, using the examples, defaults and enums it declares
+func (c *openAPIConverter) example(value interface{}, depth int) interface{} {
+	schema := c.resolve(value)
+	if schema == nil || depth > openAPIMaxDepth {
+		return nil
+	}
+
+	for _, key := range []string{"example", "default"} {
+		if example, ok := schema[key]; ok {
+			return example
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		return enum[0]
+	}
+
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		merged := map[string]interface{}{}
+		for _, item := range allOf {
+			for key, value := range asMap(c.example(item, depth+1)) {
+				merged[key] = value
+			}
+		}
+		return merged
+	}
+
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if items, ok := schema[key].([]interface{}); ok && len(items) > 0 {
+			return c.example(items[0], depth+1)
+		}
+	}
+
+	schemaType, _ := schema["type"].(string)
+	if schemaTypes, ok := schema["type"].([]interface{}); ok && len(schemaTypes) > 0 {
+		schemaType, _ = schemaTypes[0].(string)
+	}
+
+	switch {
+	case schemaType == "object" || schema["properties"] != nil:
+		object := map[string]interface{}{}
+		properties := asMap(schema["properties"])
+		for key, property := range properties {
+			object[key] = c.example(property, depth+1)
+		}
+		return object
+	case schemaType == "array":
+		return []interface{}{c.example(schema["items"], depth+1)}
+	case schemaType == "integer" || schemaType == "number":
+		return 0
+	case schemaType == "boolean":
+		return true
+	case schemaType == "string":
+		format, _ := schema["format"].(string)
+		if example, ok := openAPIStringFormats[format]; ok {
+			return example
+		}
+		return "string"
+	}
+
+	return nil
+}
+
+// firstExample returns the value of the first example of an examples map
+func (c *openAPIConverter) firstExample(value interface{}) (interface{}, bool) {
+	examples := asMap(value)
+	if len(examples) == 0 {
+		return nil, false
+	}
+
+	example, ok := c.resolve(examples[sortedKeys(examples)[0]])["value"]
+	return example, ok
+}
+
+// resolve returns the object, following its $ref when it is a reference to the same document
+func (c *openAPIConverter) resolve(value interface{}) map[string]interface{} {
+	object := asMap(value)
+
+	for visited := 0; object != nil && visited < openAPIMaxDepth; visited++ {
+		ref, ok := object["$ref"].(string)
+		if !ok {
+			return object
+		}
+
+		if !strings.HasPrefix(ref, "#/") {
+			c.warn("references the external document " + ref + " and it was not resolved")
+			return nil
+		}
+
+		var target interface{} = c.spec
+		for _, token := range strings.Split(ref[2:], "/") {
+			token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+			target = asMap(target)[token]
+		}
+		object = asMap(target)
+	}
+
+	return object
+}
+
+func (c *openAPIConverter) warn(message string) {
+	warning := c.name + " " + message
+	for _, existing := range c.warnings {
+		if existing == warning {
+			return
+		}
+	}
+
+	c.warnings = append(c.warnings, warning)
+}
+
+func asMap(value interface{}) map[string]interface{} {
+	object, _ := value.(map[string]interface{})
+	return object
+}
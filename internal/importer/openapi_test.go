@@ -0,0 +1,154 @@
+package importer_test
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/jibaru/do/internal/importer"
+)
+
+func TestOpenAPIImporter_Import(t *testing.T) {
+	let := "let {\n" +
+		"    baseUrl = env(\"BASE_URL\", \"https://api.example.com/v1\");\n" +
+		"}\n\n"
+
+	testCases := []struct {
+		name          string
+		filename      string
+		content       string
+		expected      *importer.Result
+		expectedError error
+	}{
+		{
+			name:     "success yaml specification",
+			filename: "testdata/openapi.yaml",
+			expected: &importer.Result{
+				Files: []importer.File{
+					{
+						Path: "list_todos.do",
+						Content: let +
+							"do {\n" +
+							"    method = \"GET\";\n" +
+							"    url = \"$baseUrl/todos\";\n" +
+							"    query = {\n" +
+							"        \"page\": \"1\"\n" +
+							"    };\n" +
+							"    headers = {\n" +
+							"        \"X-Request-Id\": \"3fa85f64-5717-4562-b3fc-2c963f66afa6\"\n" +
+							"    };\n" +
+							"}\n",
+					},
+					{
+						Path: "create_todo.do",
+						Content: let +
+							"do {\n" +
+							"    method = \"POST\";\n" +
+							"    url = \"$baseUrl/todos\";\n" +
+							"    headers = {\n" +
+							"        \"Content-Type\": \"application/json\"\n" +
+							"    };\n" +
+							"    body = `{\"done\":false,\"due\":\"2024-01-01\",\"owner\":{\"email\":\"user@example.com\",\"id\":\"3fa85f64-5717-4562-b3fc-2c963f66afa6\"},\"tags\":[\"home\"],\"title\":\"Buy milk\"}`;\n" +
+							"}\n",
+					},
+					{
+						Path: "get_todo.do",
+						Content: let +
+							"do {\n" +
+							"    method = \"GET\";\n" +
+							"    url = \"$baseUrl/todos/:todoId\";\n" +
+							"    params = {\n" +
+							"        \"todoId\": \"42\"\n" +
+							"    };\n" +
+							"}\n",
+					},
+					{
+						Path: "delete_todos_todo_id.do",
+						Content: let +
+							"do {\n" +
+							"    method = \"DELETE\";\n" +
+							"    url = \"$baseUrl/todos/:todoId\";\n" +
+							"    params = {\n" +
+							"        \"todoId\": \"42\"\n" +
+							"    };\n" +
+							"}\n",
+					},
+					{
+						Path: "upload_attachment.do",
+						Content: "let {\n" +
+							"    baseUrl = env(\"BASE_URL\", \"https://api.example.com/v1\");\n" +
+							"    fileFile = file(\"path/to/file\");\n" +
+							"}\n\n" +
+							"do {\n" +
+							"    method = \"POST\";\n" +
+							"    url = \"$baseUrl/todos/:todoId/attachments\";\n" +
+							"    params = {\n" +
+							"        \"todoId\": \"42\"\n" +
+							"    };\n" +
+							"    body = {\n" +
+							"        \"description\": \"string\",\n" +
+							"        \"file\": fileFile\n" +
+							"    };\n" +
+							"}\n",
+					},
+				},
+				Warnings: []string{"operation DELETE /todos/{todoId} has the cookie parameter session and it was not converted"},
+			},
+		},
+		{
+			name:    "success json specification without servers",
+			content: `{"openapi": "3.1.0", "paths": {"/health": {"head": {}}}}`,
+			expected: &importer.Result{
+				Files: []importer.File{
+					{
+						Path: "head_health.do",
+						Content: "let {\n" +
+							"    baseUrl = env(\"BASE_URL\", \"http://localhost\");\n" +
+							"}\n\n" +
+							"do {\n" +
+							"    method = \"HEAD\";\n" +
+							"    url = \"$baseUrl/health\";\n" +
+							"}\n",
+					},
+				},
+				Warnings: []string{"the specification has no servers, http://localhost was used"},
+			},
+		},
+		{
+			name:          "error swagger specification",
+			content:       `{"swagger": "2.0", "paths": {}}`,
+			expectedError: errors.New("invalid file: swagger 2.0 is not supported, convert it to OpenAPI 3 first"),
+		},
+		{
+			name:          "error not an openapi specification",
+			content:       `{"info": {}}`,
+			expectedError: errors.New("invalid file: not an OpenAPI 3 specification"),
+		},
+	}
+
+	i := importer.NewOpenAPI()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content := []byte(tc.content)
+			if tc.filename != "" {
+				content, _ = os.ReadFile(tc.filename)
+			}
+
+			result, err := i.Import(content)
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("expected %#v, got %#v", tc.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,570 @@
+package importer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jibaru/do/internal/reader"
+	"github.com/jibaru/do/internal/types"
+)
+
+var (
+	// postmanDynamicVariables defines the dynamic variables that have an equivalent function
+	postmanDynamicVariables = map[string]types.Func{
+		"$guid":         {Name: types.UuidFuncName},
+		"$randomUUID":   {Name: types.UuidFuncName},
+		"$isoTimestamp": {Name: types.DateFuncName, Args: []interface{}{types.String("ISO8601")}},
+	}
+
+	// postmanRawContentTypes defines the content type sent by Postman for each raw body language
+	postmanRawContentTypes = map[string]string{
+		"json":       "application/json",
+		"xml":        "application/xml",
+		"html":       "text/html",
+		"javascript": "application/javascript",
+		"text":       "text/plain",
+	}
+)
+
+// postmanFile defines the fields of a collection v2.1 or an environment
+type postmanFile struct {
+	Info     *postmanInfo      `json:"info"`
+	Item     []postmanItem     `json:"item"`
+	Variable []postmanVariable `json:"variable"`
+	Auth     *postmanAuth      `json:"auth"`
+	Event    []postmanEvent    `json:"event"`
+	Name     string            `json:"name"`
+	Values   []postmanVariable `json:"values"`
+}
+
+type postmanInfo struct {
+	Name string `json:"name"`
+}
+
+type postmanItem struct {
+	Name    string          `json:"name"`
+	Item    []postmanItem   `json:"item"`
+	Request *postmanRequest `json:"request"`
+	Auth    *postmanAuth    `json:"auth"`
+	Event   []postmanEvent  `json:"event"`
+}
+
+type postmanRequest struct {
+	Method string            `json:"method"`
+	Header []postmanKeyValue `json:"header"`
+	URL    postmanURL        `json:"url"`
+	Body   *postmanBody      `json:"body"`
+	Auth   *postmanAuth      `json:"auth"`
+}
+
+type postmanURL struct {
+	Raw      string            `json:"raw"`
+	Query    []postmanKeyValue `json:"query"`
+	Variable []postmanKeyValue `json:"variable"`
+}
+
+type postmanBody struct {
+	Mode       string            `json:"mode"`
+	Raw        string            `json:"raw"`
+	URLEncoded []postmanKeyValue `json:"urlencoded"`
+	FormData   []postmanKeyValue `json:"formdata"`
+	Options    struct {
+		Raw struct {
+			Language string `json:"language"`
+		} `json:"raw"`
+	} `json:"options"`
+}
+
+type postmanKeyValue struct {
+	Key      string       `json:"key"`
+	Value    postmanValue `json:"value"`
+	Disabled bool         `json:"disabled"`
+	Type     string       `json:"type"`
+	Src      interface{}  `json:"src"`
+}
+
+type postmanVariable struct {
+	Key      string       `json:"key"`
+	Value    postmanValue `json:"value"`
+	Disabled bool         `json:"disabled"`
+	Enabled  *bool        `json:"enabled"`
+}
+
+// postmanAuth defines an auth method with its parameters by key
+type postmanAuth struct {
+	Type   string
+	Params map[string]string
+}
+
+type postmanEvent struct {
+	Listen string `json:"listen"`
+	Script struct {
+		Exec postmanLines `json:"exec"`
+	} `json:"script"`
+}
+
+// postmanValue defines a value that can be written as any json scalar
+type postmanValue string
+
+// postmanLines defines a script that can be written as a string or as a list of lines
+type postmanLines []string
+
+type postmanImporter struct{}
+
+// NewPostman returns an importer that converts a Postman collection v2.1 into a tree of .do
+// files, or a Postman environment into an env file
+func NewPostman() Importer {
+	return &postmanImporter{}
+}
+
+func (i *postmanImporter) Import(content []byte) (*Result, error) {
+	file := postmanFile{}
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, NewInvalidFileError(err.Error())
+	}
+
+	if file.Item == nil && file.Values != nil {
+		return importPostmanEnvironment(file), nil
+	}
+
+	if file.Info == nil || file.Item == nil {
+		return nil, NewInvalidFileError("not a postman collection or environment")
+	}
+
+	variables := map[string]string{}
+	for _, variable := range file.Variable {
+		if !variable.Disabled {
+			variables[variableName(variable.Key)] = string(variable.Value)
+		}
+	}
+
+	result := &Result{Files: make([]File, 0), Warnings: make([]string, 0)}
+	result.Warnings = append(result.Warnings, scriptWarnings("collection "+file.Info.Name, file.Event)...)
+
+	importPostmanItems(file.Item, "", file.Auth, variables, result)
+
+	return result, nil
+}
+
+// importPostmanItems adds a file for each request and a directory for each folder of the items
+func importPostmanItems(items []postmanItem, dir string, auth *postmanAuth, variables map[string]string, result *Result) {
+	for _, item := range items {
+		itemAuth := auth
+		if item.Auth != nil && item.Auth.Type != "inherit" {
+			itemAuth = item.Auth
+		}
+
+		if item.Request == nil {
+			folder := uniquePath(result.Files, path.Join(dir, slug(item.Name)), "")
+			result.Warnings = append(result.Warnings, scriptWarnings("folder "+folder, item.Event)...)
+			importPostmanItems(item.Item, folder, itemAuth, variables, result)
+			continue
+		}
+
+		filename := uniquePath(result.Files, path.Join(dir, slug(item.Name)), reader.DoFileExtension)
+		name := "request " + filename
+		result.Warnings = append(result.Warnings, scriptWarnings(name, item.Event)...)
+
+		if item.Request.Auth != nil && item.Request.Auth.Type != "inherit" {
+			itemAuth = item.Request.Auth
+		}
+
+		c := &postmanConverter{name: name, variables: variables, let: types.Map{}, warnings: make([]string, 0)}
+		doFile, ok := c.toDoFile(*item.Request, itemAuth)
+		result.Warnings = append(result.Warnings, c.warnings...)
+		if !ok {
+			continue
+		}
+
+		content, err := render(*doFile)
+		if err != nil {
+			result.Warnings = append(result.Warnings, name+": "+err.Error()+", the request was not imported")
+			continue
+		}
+
+		result.Files = append(result.Files, File{Path: filename, Content: content})
+	}
+}
+
+// importPostmanEnvironment returns an env file with the enabled values of the environment
+func importPostmanEnvironment(file postmanFile) *Result {
+	content := strings.Builder{}
+	warnings := make([]string, 0)
+
+	if file.Name != "" {
+		content.WriteString("# " + file.Name + "\n")
+	}
+
+	for _, value := range file.Values {
+		if value.Enabled != nil && !*value.Enabled {
+			continue
+		}
+
+		if strings.ContainsAny(string(value.Value), "\r\n") {
+			warnings = append(warnings, "variable "+value.Key+" has multiple lines and was not converted")
+			continue
+		}
+
+		content.WriteString(variableName(value.Key) + "=" + envValue(string(value.Value)) + "\n")
+	}
+
+	name := slug(file.Name)
+	if name == "" {
+		name = "environment"
+	}
+
+	return &Result{
+		Files:    []File{{Path: name + ".env", Content: content.String()}},
+		Warnings: warnings,
+	}
+}
+
+// postmanConverter converts a request, declaring in the let section the variables it uses
+type postmanConverter struct {
+	name      string
+	variables map[string]string
+	let       types.Map
+	warnings  []string
+}
+
+func (c *postmanConverter) toDoFile(request postmanRequest, auth *postmanAuth) (*types.DoFile, bool) {
+	rawURL, rawQuery := request.URL.Raw, ""
+	if idx := strings.Index(rawURL, "#"); idx != -1 {
+		rawURL = rawURL[:idx]
+	}
+	if idx := strings.Index(rawURL, "?"); idx != -1 {
+		rawURL, rawQuery = rawURL[:idx], rawURL[idx+1:]
+	}
+
+	if strings.TrimSpace(rawURL) == "" {
+		c.warn("has no url and was not imported")
+		return nil, false
+	}
+
+	method := strings.ToUpper(request.Method)
+	if method == "" {
+		method = "GET"
+	}
+
+	doFile := &types.DoFile{
+		Do: types.Do{
+			Method:  types.String(method),
+			URL:     types.String(c.convert(rawURL, nil)),
+			Params:  c.toMap(request.URL.Variable),
+			Query:   c.toQuery(request.URL.Query, rawQuery),
+			Headers: c.toMap(request.Header),
+		},
+	}
+
+	c.setAuth(doFile, auth)
+	c.setBody(doFile, request.Body)
+
+	if len(c.let) > 0 {
+		doFile.Let.Variables = c.let
+	}
+
+	return doFile, true
+}
+
+func (c *postmanConverter) toMap(values []postmanKeyValue) types.Map {
+	result := types.Map{}
+	for _, value := range values {
+		if !value.Disabled && value.Key != "" {
+			result[value.Key] = types.String(c.convert(string(value.Value), nil))
+		}
+	}
+
+	return result
+}
+
+// toQuery returns the query params, read from the raw url when they are not listed
+func (c *postmanConverter) toQuery(values []postmanKeyValue, rawQuery string) types.Map {
+	if values == nil && rawQuery != "" {
+		for _, part := range strings.Split(rawQuery, "&") {
+			keyValue := strings.SplitN(part, "=", 2)
+			value := postmanKeyValue{Key: keyValue[0]}
+			if len(keyValue) == 2 {
+				value.Value = postmanValue(keyValue[1])
+			}
+			values = append(values, value)
+		}
+	}
+
+	query := types.Map{}
+	for _, value := range values {
+		if value.Disabled || value.Key == "" {
+			continue
+		}
+		if _, ok := query[value.Key]; ok {
+			c.warn("repeats the query param " + value.Key + ", only the last value was kept")
+		}
+		query[value.Key] = types.String(c.convert(string(value.Value), nil))
+	}
+
+	return query
+}
+
+func (c *postmanConverter) setAuth(doFile *types.DoFile, auth *postmanAuth) {
+	if auth == nil || auth.Type == "noauth" || auth.Type == "inherit" {
+		return
+	}
+
+	headers := doFile.Do.Headers
+	if hasHeader(headers, "Authorization") && auth.Type != "apikey" {
+		return
+	}
+
+	switch auth.Type {
+	case "bearer":
+		headers["Authorization"] = types.String("Bearer " + c.convert(auth.Params["token"], nil))
+	case "basic":
+		credentials := auth.Params["username"] + ":" + auth.Params["password"]
+		if template.MatchString(credentials) {
+			c.warn("uses variables in basic auth and it was not converted")
+			return
+		}
+		headers["Authorization"] = types.String("Basic " + base64.StdEncoding.EncodeToString([]byte(credentials)))
+	case "apikey":
+		key, value := c.convert(auth.Params["key"], nil), c.convert(auth.Params["value"], nil)
+		if auth.Params["in"] == "query" {
+			doFile.Do.Query[key] = types.String(value)
+		} else {
+			headers[key] = types.String(value)
+		}
+	default:
+		c.warn("uses " + auth.Type + " auth and it was not converted")
+	}
+}
+
+func (c *postmanConverter) setBody(doFile *types.DoFile, body *postmanBody) {
+	if body == nil {
+		return
+	}
+
+	headers := doFile.Do.Headers
+
+	switch body.Mode {
+	case "raw":
+		if body.Raw == "" {
+			return
+		}
+		if contentType, ok := postmanRawContentTypes[body.Options.Raw.Language]; ok && !hasHeader(headers, "Content-Type") {
+			headers["Content-Type"] = types.String(contentType)
+		}
+		doFile.Do.Body = types.String(c.convert(body.Raw, nil))
+	case "urlencoded":
+		parts := make([]string, 0, len(body.URLEncoded))
+		for _, value := range body.URLEncoded {
+			if !value.Disabled {
+				parts = append(parts, c.convert(value.Key, urlEncode)+"="+c.convert(string(value.Value), urlEncode))
+			}
+		}
+		if len(parts) == 0 {
+			return
+		}
+		if !hasHeader(headers, "Content-Type") {
+			headers["Content-Type"] = types.String("application/x-www-form-urlencoded")
+		}
+		doFile.Do.Body = types.String(strings.Join(parts, "&"))
+	case "formdata":
+		form := types.Map{}
+		for _, value := range body.FormData {
+			if value.Disabled {
+				continue
+			}
+			if value.Type != "file" {
+				form[value.Key] = types.String(c.convert(string(value.Value), nil))
+				continue
+			}
+			src, ok := value.Src.(string)
+			if !ok || src == "" {
+				c.warn("has the form file " + value.Key + " without a single path and it was not converted")
+				continue
+			}
+			form[value.Key] = types.File{Path: src}
+		}
+		if len(form) > 0 {
+			doFile.Do.Body = form
+		}
+	case "":
+	default:
+		c.warn("has a " + body.Mode + " body and it was not converted")
+	}
+}
+
+// convert replaces the {{variable}} references with $variable, encoding the text around them
+func (c *postmanConverter) convert(text string, encode func(string) string) string {
+	return replaceTemplates(text, encode, func(reference string) (string, bool) {
+		name := variableName(reference)
+
+		if strings.HasPrefix(reference, "$") {
+			fn, ok := postmanDynamicVariables[reference]
+			if !ok {
+				c.warn("uses the dynamic variable " + reference + " and it was not converted")
+				return "", false
+			}
+			c.let[name] = fn
+		} else if _, ok := c.let[name]; !ok {
+			args := []interface{}{types.String(name)}
+			if value, ok := c.variables[name]; ok {
+				args = append(args, types.String(value))
+			}
+			c.let[name] = types.Func{Name: types.EnvFuncName, Args: args}
+		}
+
+		return name, true
+	})
+}
+
+func (c *postmanConverter) warn(message string) {
+	c.warnings = append(c.warnings, c.name+" "+message)
+}
+
+func (a *postmanAuth) UnmarshalJSON(data []byte) error {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(fields["type"], &a.Type); err != nil {
+		return err
+	}
+
+	a.Params = map[string]string{}
+	params := make([]postmanKeyValue, 0)
+	if err := json.Unmarshal(fields[a.Type], &params); err == nil {
+		for _, param := range params {
+			a.Params[param.Key] = string(param.Value)
+		}
+	}
+
+	return nil
+}
+
+func (u *postmanURL) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &u.Raw); err == nil {
+		return nil
+	}
+
+	type url postmanURL
+	return json.Unmarshal(data, (*url)(u))
+}
+
+func (v *postmanValue) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	switch val := value.(type) {
+	case nil:
+		*v = ""
+	case string:
+		*v = postmanValue(val)
+	case float64:
+		*v = postmanValue(strconv.FormatFloat(val, 'f', -1, 64))
+	default:
+		*v = postmanValue(strings.TrimSpace(string(data)))
+	}
+
+	return nil
+}
+
+func (l *postmanLines) UnmarshalJSON(data []byte) error {
+	var line string
+	if err := json.Unmarshal(data, &line); err == nil {
+		*l = postmanLines{line}
+		return nil
+	}
+
+	return json.Unmarshal(data, (*[]string)(l))
+}
+
+// scriptWarnings returns a warning for each script of the events, as they can not be converted
+func scriptWarnings(name string, events []postmanEvent) []string {
+	warnings := make([]string, 0)
+	for _, event := range events {
+		if strings.TrimSpace(strings.Join(event.Script.Exec, "")) != "" {
+			warnings = append(warnings, name+" has a "+event.Listen+" script and it was not converted")
+		}
+	}
+
+	return warnings
+}
+
+// variableName returns a valid let variable name for the postman variable
+func variableName(name string) string {
+	result := strings.Builder{}
+	for i, ch := range strings.TrimPrefix(name, "$") {
+		if i == 0 && unicode.IsDigit(ch) {
+			result.WriteRune('_')
+		}
+		if unicode.IsLetter(ch) || unicode.IsDigit(ch) || ch == '_' {
+			result.WriteRune(ch)
+		} else {
+			result.WriteRune('_')
+		}
+	}
+
+	if types.IsReservedKeyword(result.String()) || types.IsFuncName(result.String()) {
+		result.WriteRune('_')
+	}
+
+	return result.String()
+}
+
+// slug returns the name in lower case with the characters that are not letters or digits as underscores
+func slug(name string) string {
+	result := strings.Builder{}
+	separator := false
+
+	for _, ch := range strings.ToLower(strings.TrimSpace(name)) {
+		if unicode.IsLetter(ch) || unicode.IsDigit(ch) {
+			if separator && result.Len() > 0 {
+				result.WriteRune('_')
+			}
+			result.WriteRune(ch)
+			separator = false
+		} else {
+			separator = true
+		}
+	}
+
+	if result.Len() == 0 {
+		return "unnamed"
+	}
+
+	return result.String()
+}
+
+// uniquePath returns the path with the extension, adding a number when it is already used by a file
+func uniquePath(files []File, base, extension string) string {
+	used := func(candidate string) bool {
+		for _, file := range files {
+			if file.Path == candidate || strings.HasPrefix(file.Path, candidate+"/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	candidate := base + extension
+	for n := 2; used(candidate); n++ {
+		candidate = base + "_" + strconv.Itoa(n) + extension
+	}
+
+	return candidate
+}
+
+// envValue returns the value as it is written in an env file
+func envValue(value string) string {
+	if value != strings.TrimSpace(value) || strings.HasPrefix(value, `"`) || strings.HasPrefix(value, "'") {
+		return `"` + value + `"`
+	}
+
+	return value
+}
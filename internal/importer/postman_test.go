@@ -0,0 +1,155 @@
+package importer_test
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/jibaru/do/internal/importer"
+)
+
+func TestPostmanImporter_Import(t *testing.T) {
+	testCases := []struct {
+		name          string
+		filename      string
+		content       string
+		expected      *importer.Result
+		expectedError error
+	}{
+		{
+			name:     "success collection",
+			filename: "testdata/collection.json",
+			expected: &importer.Result{
+				Files: []importer.File{
+					{
+						Path: "todos/get_todo.do",
+						Content: "let {\n" +
+							"    base_url = env(\"base_url\", \"http://localhost:8080\");\n" +
+							"    page = env(\"page\");\n" +
+							"    token = env(\"token\");\n" +
+							"}\n\n" +
+							"do {\n" +
+							"    method = \"GET\";\n" +
+							"    url = \"$base_url/todos/:id\";\n" +
+							"    params = {\n" +
+							"        \"id\": \"7\"\n" +
+							"    };\n" +
+							"    query = {\n" +
+							"        \"expand\": \"true\",\n" +
+							"        \"page\": \"$page\"\n" +
+							"    };\n" +
+							"    headers = {\n" +
+							"        \"Accept\": \"application/json\",\n" +
+							"        \"Authorization\": \"Bearer $token\"\n" +
+							"    };\n" +
+							"}\n",
+					},
+					{
+						Path: "todos/create_todo.do",
+						Content: "let {\n" +
+							"    base_url = env(\"base_url\", \"http://localhost:8080\");\n" +
+							"    guid = uuid();\n" +
+							"    token = env(\"token\");\n" +
+							"}\n\n" +
+							"do {\n" +
+							"    method = \"POST\";\n" +
+							"    url = \"$base_url/todos\";\n" +
+							"    headers = {\n" +
+							"        \"Authorization\": \"Bearer $token\",\n" +
+							"        \"Content-Type\": \"application/json\"\n" +
+							"    };\n" +
+							"    body = `{\"id\": \"$guid\", \"title\": \"Buy milk\"}`;\n" +
+							"}\n",
+					},
+					{
+						Path: "login.do",
+						Content: "let {\n" +
+							"    base_url = env(\"base_url\", \"http://localhost:8080\");\n" +
+							"    user = env(\"user\");\n" +
+							"}\n\n" +
+							"do {\n" +
+							"    method = \"POST\";\n" +
+							"    url = \"$base_url/login\";\n" +
+							"    headers = {\n" +
+							"        \"Content-Type\": \"application/x-www-form-urlencoded\"\n" +
+							"    };\n" +
+							"    body = \"user=$user&password=a%20b%26c\";\n" +
+							"}\n",
+					},
+					{
+						Path: "upload_avatar.do",
+						Content: "let {\n" +
+							"    avatarFile = file(\"/tmp/avatar.png\");\n" +
+							"    base_url = env(\"base_url\", \"http://localhost:8080\");\n" +
+							"}\n\n" +
+							"do {\n" +
+							"    method = \"POST\";\n" +
+							"    url = \"$base_url/avatar\";\n" +
+							"    body = {\n" +
+							"        \"avatar\": avatarFile,\n" +
+							"        \"name\": \"john\"\n" +
+							"    };\n" +
+							"}\n",
+					},
+				},
+				Warnings: []string{
+					"folder todos has a prerequest script and it was not converted",
+					"request todos/get_todo.do has a test script and it was not converted",
+					"request upload_avatar.do uses oauth1 auth and it was not converted",
+				},
+			},
+		},
+		{
+			name:     "success environment",
+			filename: "testdata/environment.json",
+			expected: &importer.Result{
+				Files: []importer.File{
+					{
+						Path: "local_dev.env",
+						Content: "# Local Dev\n" +
+							"base_url=http://localhost:9090\n" +
+							"token=secret\n" +
+							"padded=\" a \"\n",
+					},
+				},
+				Warnings: []string{"variable cert has multiple lines and was not converted"},
+			},
+		},
+		{
+			name:          "error not a postman file",
+			content:       `{"openapi": "3.0.0"}`,
+			expectedError: errors.New("invalid file: not a postman collection or environment"),
+		},
+		{
+			name:          "error invalid json",
+			content:       `{`,
+			expectedError: errors.New("invalid file: unexpected end of JSON input"),
+		},
+	}
+
+	i := importer.NewPostman()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content := []byte(tc.content)
+			if tc.filename != "" {
+				content, _ = os.ReadFile(tc.filename)
+			}
+
+			result, err := i.Import(content)
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("expected %#v, got %#v", tc.expected, result)
+			}
+		})
+	}
+}
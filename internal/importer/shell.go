@@ -0,0 +1,104 @@
+package importer
+
+import (
+	"strings"
+)
+
+// shellSplit splits a command line into words as a POSIX shell does, supporting
+// single quotes, double quotes, $'...' quotes, backslash escapes and line continuations
+func shellSplit(command string) ([]string, error) {
+	words := make([]string, 0)
+	current := strings.Builder{}
+	inWord := false
+
+	for i := 0; i < len(command); i++ {
+		ch := command[i]
+
+		switch {
+		case ch == '\\':
+			if i+1 >= len(command) {
+				return nil, NewInvalidCommandError("unterminated escape")
+			}
+			i++
+			if command[i] == '\n' {
+				// line continuation
+				continue
+			}
+			current.WriteByte(command[i])
+			inWord = true
+		case ch == '\'':
+			end := strings.IndexByte(command[i+1:], '\'')
+			if end == -1 {
+				return nil, NewInvalidCommandError("unterminated single quote")
+			}
+			current.WriteString(command[i+1 : i+1+end])
+			i += end + 1
+			inWord = true
+		case ch == '$' && i+1 < len(command) && command[i+1] == '\'':
+			value, length, err := ansiQuoted(command[i+2:])
+			if err != nil {
+				return nil, err
+			}
+			current.WriteString(value)
+			i += length + 1
+			inWord = true
+		case ch == '"':
+			i++
+			for ; i < len(command) && command[i] != '"'; i++ {
+				if command[i] == '\\' && i+1 < len(command) && strings.IndexByte("$`\"\\\n", command[i+1]) != -1 {
+					i++
+					if command[i] == '\n' {
+						continue
+					}
+				}
+				current.WriteByte(command[i])
+			}
+			if i >= len(command) {
+				return nil, NewInvalidCommandError("unterminated double quote")
+			}
+			inWord = true
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			if inWord {
+				words = append(words, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteByte(ch)
+			inWord = true
+		}
+	}
+
+	if inWord {
+		words = append(words, current.String())
+	}
+
+	return words, nil
+}
+
+// ansiQuoted returns the value of a $'...' string whose content starts the text,
+// and the length read including the closing quote
+func ansiQuoted(text string) (string, int, error) {
+	escapes := map[byte]string{'n': "\n", 't': "\t", 'r': "\r", '\\': "\\", '\'': "'", '"': "\""}
+	value := strings.Builder{}
+
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\'':
+			return value.String(), i + 1, nil
+		case '\\':
+			if i+1 < len(text) {
+				if escaped, ok := escapes[text[i+1]]; ok {
+					value.WriteString(escaped)
+					i++
+					continue
+				}
+			}
+			value.WriteByte(text[i])
+		default:
+			value.WriteByte(text[i])
+		}
+	}
+
+	return "", 0, NewInvalidCommandError("unterminated $' quote")
+}
@@ -0,0 +1,38 @@
+package jsonpath
+
+type InvalidPathError struct {
+	path   string
+	reason string
+}
+
+type PathNotFoundError struct {
+	path string
+}
+
+type InvalidDocumentError struct {
+	err error
+}
+
+func NewInvalidPathError(path, reason string) error {
+	return InvalidPathError{path, reason}
+}
+
+func NewPathNotFoundError(path string) error {
+	return PathNotFoundError{path}
+}
+
+func NewInvalidDocumentError(err error) error {
+	return InvalidDocumentError{err}
+}
+
+func (e InvalidPathError) Error() string {
+	return "invalid json path " + e.path + ": " + e.reason
+}
+
+func (e PathNotFoundError) Error() string {
+	return "json path " + e.path + " not found"
+}
+
+func (e InvalidDocumentError) Error() string {
+	return "invalid json document: " + e.err.Error()
+}
@@ -0,0 +1,207 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Path defines the steps of a json path, each one is a key of an object, an index of an array
+// or every element of an array
+type Path struct {
+	raw   string
+	steps []interface{}
+}
+
+// wildcard defines the step of every element of an array, written as [*]
+type wildcard struct{}
+
+// Parse returns the path written like $.users[0].name, $['first name'] or $.users[*].id.
+// Negative indexes start from the end of the array.
+func Parse(raw string) (*Path, error) {
+	rest := strings.TrimSpace(raw)
+	if !strings.HasPrefix(rest, "$") {
+		return nil, NewInvalidPathError(raw, "it must start with $")
+	}
+	rest = rest[1:]
+
+	path := &Path{raw: raw, steps: make([]interface{}, 0)}
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end == -1 {
+				end = len(rest) - 1
+			}
+			key := rest[1 : end+1]
+			if key == "" {
+				return nil, NewInvalidPathError(raw, "empty key")
+			}
+			path.steps = append(path.steps, key)
+			rest = rest[end+1:]
+		case '[':
+			end := strings.Index(rest, "]")
+			if end == -1 {
+				return nil, NewInvalidPathError(raw, "missing ]")
+			}
+			inside := strings.TrimSpace(rest[1:end])
+			if len(inside) >= 2 && (inside[0] == '\'' || inside[0] == '"') && inside[len(inside)-1] == inside[0] {
+				path.steps = append(path.steps, inside[1:len(inside)-1])
+			} else if inside == "*" {
+				path.steps = append(path.steps, wildcard{})
+			} else if index, err := strconv.Atoi(inside); err == nil {
+				path.steps = append(path.steps, index)
+			} else {
+				return nil, NewInvalidPathError(raw, "invalid index "+inside)
+			}
+			rest = rest[end+1:]
+		default:
+			return nil, NewInvalidPathError(raw, "unexpected "+string(rest[0]))
+		}
+	}
+
+	return path, nil
+}
+
+// Get returns the value at the path of a document decoded by encoding/json.
+// The values of the elements of an array are returned as an array.
+func (p *Path) Get(document interface{}) (interface{}, error) {
+	return p.get(document, p.steps)
+}
+
+func (p *Path) get(current interface{}, steps []interface{}) (interface{}, error) {
+	if len(steps) == 0 {
+		return current, nil
+	}
+
+	switch key := steps[0].(type) {
+	case string:
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, NewPathNotFoundError(p.raw)
+		}
+		if current, ok = object[key]; !ok {
+			return nil, NewPathNotFoundError(p.raw)
+		}
+	case int:
+		array, ok := current.([]interface{})
+		if !ok {
+			return nil, NewPathNotFoundError(p.raw)
+		}
+		if key < 0 {
+			key += len(array)
+		}
+		if key < 0 || key >= len(array) {
+			return nil, NewPathNotFoundError(p.raw)
+		}
+		current = array[key]
+	case wildcard:
+		array, ok := current.([]interface{})
+		if !ok {
+			return nil, NewPathNotFoundError(p.raw)
+		}
+		values := make([]interface{}, 0, len(array))
+		for _, element := range array {
+			value, err := p.get(element, steps[1:])
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+
+	return p.get(current, steps[1:])
+}
+
+// Replace sets the value at the path of a document decoded by encoding/json, in every element of
+// an array for [*], and returns the document, which is the value itself for the $ path
+func (p *Path) Replace(document interface{}, value interface{}) (interface{}, error) {
+	return p.replace(document, p.steps, value)
+}
+
+func (p *Path) replace(current interface{}, steps []interface{}, value interface{}) (interface{}, error) {
+	if len(steps) == 0 {
+		return value, nil
+	}
+
+	switch key := steps[0].(type) {
+	case string:
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, NewPathNotFoundError(p.raw)
+		}
+		child, ok := object[key]
+		if !ok {
+			return nil, NewPathNotFoundError(p.raw)
+		}
+		replaced, err := p.replace(child, steps[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		object[key] = replaced
+	case int:
+		array, ok := current.([]interface{})
+		if !ok {
+			return nil, NewPathNotFoundError(p.raw)
+		}
+		if key < 0 {
+			key += len(array)
+		}
+		if key < 0 || key >= len(array) {
+			return nil, NewPathNotFoundError(p.raw)
+		}
+		replaced, err := p.replace(array[key], steps[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		array[key] = replaced
+	case wildcard:
+		array, ok := current.([]interface{})
+		if !ok {
+			return nil, NewPathNotFoundError(p.raw)
+		}
+		for i := range array {
+			replaced, err := p.replace(array[i], steps[1:], value)
+			if err != nil {
+				return nil, err
+			}
+			array[i] = replaced
+		}
+	}
+
+	return current, nil
+}
+
+// String returns the path as it was written
+func (p *Path) String() string {
+	return p.raw
+}
+
+// Get returns the value at the path of the json content
+func Get(content []byte, raw string) (interface{}, error) {
+	path, err := Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := Decode(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return path.Get(document)
+}
+
+// Decode returns the json content as maps, slices and json.Number values
+func Decode(content []byte) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(string(content)))
+	decoder.UseNumber()
+
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, NewInvalidDocumentError(err)
+	}
+
+	return document, nil
+}
@@ -0,0 +1,177 @@
+package jsonpath_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jibaru/do/internal/jsonpath"
+)
+
+func TestGet(t *testing.T) {
+	content := []byte(`{"data": {"token": "abc", "first name": "John", "items": [{"id": 1}, {"id": 2.5}], "active": true}}`)
+
+	testCases := []struct {
+		name          string
+		path          string
+		expected      interface{}
+		expectedError error
+	}{
+		{
+			name:     "success root",
+			path:     "$",
+			expected: map[string]interface{}{"data": nil},
+		},
+		{
+			name:     "success nested key",
+			path:     "$.data.token",
+			expected: "abc",
+		},
+		{
+			name:     "success quoted key",
+			path:     "$.data['first name']",
+			expected: "John",
+		},
+		{
+			name:     "success index",
+			path:     "$.data.items[0].id",
+			expected: json.Number("1"),
+		},
+		{
+			name:     "success negative index",
+			path:     `$["data"].items[-1].id`,
+			expected: json.Number("2.5"),
+		},
+		{
+			name:     "success bool",
+			path:     "$.data.active",
+			expected: true,
+		},
+		{
+			name:          "error not found",
+			path:          "$.data.items[2]",
+			expectedError: errors.New("json path $.data.items[2] not found"),
+		},
+		{
+			name:          "error key of array",
+			path:          "$.data.items.id",
+			expectedError: errors.New("json path $.data.items.id not found"),
+		},
+		{
+			name:     "success wildcard",
+			path:     "$.data.items[*].id",
+			expected: []interface{}{json.Number("1"), json.Number("2.5")},
+		},
+		{
+			name:          "error wildcard of object",
+			path:          "$.data[*]",
+			expectedError: errors.New("json path $.data[*] not found"),
+		},
+		{
+			name:          "error without root",
+			path:          "data.token",
+			expectedError: errors.New("invalid json path data.token: it must start with $"),
+		},
+		{
+			name:          "error invalid index",
+			path:          "$.data.items[first]",
+			expectedError: errors.New("invalid json path $.data.items[first]: invalid index first"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := jsonpath.Get(content, tc.path)
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if object, ok := value.(map[string]interface{}); ok {
+				// only the keys of objects are compared
+				for key := range object {
+					object[key] = nil
+				}
+			}
+
+			if !reflect.DeepEqual(value, tc.expected) {
+				t.Errorf("expected %#v, got %#v", tc.expected, value)
+			}
+		})
+	}
+}
+
+func TestPath_Replace(t *testing.T) {
+	content := `{"id": 7, "items": [{"id": 1, "name": "a"}, {"id": 2, "name": "b"}]}`
+
+	testCases := []struct {
+		name          string
+		path          string
+		expected      string
+		expectedError error
+	}{
+		{
+			name:     "success key",
+			path:     "$.id",
+			expected: `{"id":"x","items":[{"id":1,"name":"a"},{"id":2,"name":"b"}]}`,
+		},
+		{
+			name:     "success negative index",
+			path:     "$.items[-1].name",
+			expected: `{"id":7,"items":[{"id":1,"name":"a"},{"id":2,"name":"x"}]}`,
+		},
+		{
+			name:     "success wildcard",
+			path:     "$.items[*].id",
+			expected: `{"id":7,"items":[{"id":"x","name":"a"},{"id":"x","name":"b"}]}`,
+		},
+		{
+			name:     "success root",
+			path:     "$",
+			expected: `"x"`,
+		},
+		{
+			name:          "error not found",
+			path:          "$.items[2].id",
+			expectedError: errors.New("json path $.items[2].id not found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			document, err := jsonpath.Decode([]byte(content))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			path, err := jsonpath.Parse(tc.path)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			document, err = path.Replace(document, "x")
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if tc.expectedError != nil {
+				return
+			}
+
+			result, _ := json.Marshal(document)
+			if string(result) != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package lsp
+
+import (
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// document defines an open .do file
+type document struct {
+	uri  string
+	text string
+}
+
+// statement defines a key assigned at the first level of a section
+type statement struct {
+	section string
+	key     string
+	offset  int
+}
+
+// sectionSpan defines the offsets of the braces of a section
+type sectionSpan struct {
+	name  string
+	start int
+	end   int
+}
+
+// filename returns the path of the document from its uri
+func (d *document) filename() string {
+	u, err := url.Parse(d.uri)
+	if err != nil || u.Scheme != "file" {
+		return d.uri
+	}
+
+	return u.Path
+}
+
+// offsetAt returns the byte offset of a position, where the character is measured in UTF-16 code units
+func (d *document) offsetAt(pos Position) int {
+	i := 0
+	for line := 0; line < pos.Line && i < len(d.text); i++ {
+		if d.text[i] == '\n' {
+			line++
+		}
+	}
+
+	for units := 0; i < len(d.text) && d.text[i] != '\n' && units < pos.Character; {
+		r, size := utf8.DecodeRuneInString(d.text[i:])
+		units += utf16Len(r)
+		i += size
+	}
+
+	return i
+}
+
+// positionAt returns the position of a byte offset
+func (d *document) positionAt(offset int) Position {
+	pos := Position{}
+	for i, r := range d.text {
+		if i >= offset {
+			break
+		}
+
+		if r == '\n' {
+			pos.Line++
+			pos.Character = 0
+		} else {
+			pos.Character += utf16Len(r)
+		}
+	}
+
+	return pos
+}
+
+// rangeOf returns the range between two byte offsets
+func (d *document) rangeOf(start, end int) Range {
+	return Range{Start: d.positionAt(start), End: d.positionAt(end)}
+}
+
+// wordAt returns the identifier around the offset and the offset where it starts
+func (d *document) wordAt(offset int) (string, int) {
+	start := offset
+	for start > 0 && isIdentifierChar(d.text[start-1]) {
+		start--
+	}
+
+	end := offset
+	for end < len(d.text) && isIdentifierChar(d.text[end]) {
+		end++
+	}
+
+	return d.text[start:end], start
+}
+
+// sectionAt returns the name of the section that contains the offset
+func (d *document) sectionAt(offset int) string {
+	_, spans := d.scan()
+	for _, span := range spans {
+		if offset > span.start && offset <= span.end {
+			return span.name
+		}
+	}
+
+	return ""
+}
+
+// sectionStart returns the offset where the section starts, or 0 when it is not found
+func (d *document) sectionStart(section string) int {
+	_, spans := d.scan()
+	for _, span := range spans {
+		if span.name == section {
+			return span.start
+		}
+	}
+
+	return 0
+}
+
+// declaration returns the statement that declares the key in the section
+func (d *document) declaration(section, key string) (statement, bool) {
+	statements, _ := d.scan()
+	for _, st := range statements {
+		if st.section == section && st.key == key {
+			return st, true
+		}
+	}
+
+	return statement{}, false
+}
+
+// keys returns the keys assigned in the section
+func (d *document) keys(section string) []string {
+	keys := make([]string, 0)
+	statements, _ := d.scan()
+	for _, st := range statements {
+		if st.section == section {
+			keys = append(keys, st.key)
+		}
+	}
+
+	return keys
+}
+
+// scan returns the statements and the sections of the document, ignoring
+// the content of strings and comments
+func (d *document) scan() ([]statement, []sectionSpan) {
+	statements := make([]statement, 0)
+	spans := make([]sectionSpan, 0)
+
+	text := d.text
+	depth := 0
+	section := ""
+	lastWord := ""
+	atStatementStart := false
+	inQuotes, inBackticks, inComment := false, false, false
+
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+
+		switch {
+		case inComment:
+			inComment = ch != '\n'
+		case inQuotes:
+			inQuotes = ch != '"'
+		case inBackticks:
+			inBackticks = ch != '`'
+		case ch == '/' && i+1 < len(text) && text[i+1] == '/':
+			inComment = true
+		case ch == '"':
+			inQuotes = true
+			atStatementStart = false
+		case ch == '`':
+			inBackticks = true
+			atStatementStart = false
+		case ch == '{':
+			if depth == 0 {
+				section = lastWord
+				spans = append(spans, sectionSpan{name: section, start: i, end: len(text)})
+				atStatementStart = true
+			} else {
+				atStatementStart = false
+			}
+			depth++
+		case ch == '}':
+			if depth == 1 && len(spans) > 0 {
+				spans[len(spans)-1].end = i
+				section = ""
+			}
+			if depth > 0 {
+				depth--
+			}
+		case ch == ';':
+			atStatementStart = depth == 1
+		case isIdentifierChar(ch):
+			end := i
+			for end < len(text) && isIdentifierChar(text[end]) {
+				end++
+			}
+
+			word := text[i:end]
+			if depth == 0 {
+				lastWord = word
+			} else if depth == 1 && atStatementStart {
+				statements = append(statements, statement{section: section, key: word, offset: i})
+			}
+
+			atStatementStart = false
+			i = end - 1
+		case strings.ContainsRune(" \t\r\n", rune(ch)):
+		default:
+			atStatementStart = false
+		}
+	}
+
+	return statements, spans
+}
+
+func isIdentifierChar(ch byte) bool {
+	return ch == '_' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9'
+}
+
+func utf16Len(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+
+	return 1
+}
@@ -0,0 +1,25 @@
+package lsp
+
+type InvalidHeaderError struct {
+	header string
+}
+
+type DocumentNotFoundError struct {
+	uri string
+}
+
+func NewInvalidHeaderError(header string) error {
+	return InvalidHeaderError{header}
+}
+
+func NewDocumentNotFoundError(uri string) error {
+	return DocumentNotFoundError{uri}
+}
+
+func (e InvalidHeaderError) Error() string {
+	return "invalid header: " + e.header
+}
+
+func (e DocumentNotFoundError) Error() string {
+	return "document not found: " + e.uri
+}
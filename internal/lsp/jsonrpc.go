@@ -0,0 +1,67 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const contentLengthHeader = "Content-Length"
+
+// readMessage reads a message framed with a Content-Length header
+func readMessage(r *bufio.Reader) ([]byte, error) {
+	length := -1
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			return nil, NewInvalidHeaderError(line)
+		}
+
+		if strings.EqualFold(strings.TrimSpace(parts[0]), contentLengthHeader) {
+			length, err = strconv.Atoi(strings.TrimSpace(parts[1]))
+			if err != nil {
+				return nil, NewInvalidHeaderError(line)
+			}
+		}
+	}
+
+	if length < 0 {
+		return nil, NewInvalidHeaderError(contentLengthHeader + " not found")
+	}
+
+	content := make([]byte, length)
+	if _, err := io.ReadFull(r, content); err != nil {
+		return nil, err
+	}
+
+	return content, nil
+}
+
+// writeMessage writes the value as json framed with a Content-Length header
+func writeMessage(w io.Writer, value interface{}) error {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	if _, err = fmt.Fprintf(w, "%s: %d\r\n\r\n", contentLengthHeader, len(content)); err != nil {
+		return err
+	}
+
+	_, err = w.Write(content)
+	return err
+}
@@ -0,0 +1,244 @@
+package lsp_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jibaru/do/internal/checker"
+	"github.com/jibaru/do/internal/formatter"
+	"github.com/jibaru/do/internal/lsp"
+	"github.com/jibaru/do/internal/parser"
+	"github.com/jibaru/do/internal/types"
+)
+
+const (
+	testURI  = "file:///tmp/test.do"
+	testText = "let {\n    base = \"http://localhost\";\n    id = 1;\n}\n\ndo {\n  method = \"GET\";\n    url = \"$base/:id\";\n    params = {\"id\": id};\n}\n"
+)
+
+func TestServer_Serve(t *testing.T) {
+	docChecker := &checker.Mock{
+		CheckContentFn: func(filename string, content types.FileReaderContent) []checker.Problem {
+			return []checker.Problem{{Filename: filename, Section: types.LetSection, Subject: "id", Message: "variable id is declared but not used"}}
+		},
+	}
+	docParser := &parser.Mock{
+		ParseFromContentFn: func(content types.FileReaderContent) (*types.DoFile, error) {
+			return &types.DoFile{
+				Let: types.Let{
+					Variables: map[string]interface{}{
+						"base": types.String("http://localhost"),
+						"id":   types.Int(1),
+					},
+				},
+			}, nil
+		},
+	}
+
+	requests := []interface{}{
+		map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": map[string]interface{}{}},
+		map[string]interface{}{"jsonrpc": "2.0", "method": "textDocument/didOpen", "params": map[string]interface{}{
+			"textDocument": map[string]interface{}{"uri": testURI, "languageId": "do", "version": 1, "text": testText},
+		}},
+		map[string]interface{}{"jsonrpc": "2.0", "id": 2, "method": "textDocument/hover", "params": position(8, 21)},
+		map[string]interface{}{"jsonrpc": "2.0", "id": 3, "method": "textDocument/definition", "params": position(8, 21)},
+		map[string]interface{}{"jsonrpc": "2.0", "id": 4, "method": "textDocument/completion", "params": position(9, 0)},
+		map[string]interface{}{"jsonrpc": "2.0", "id": 5, "method": "textDocument/formatting", "params": map[string]interface{}{
+			"textDocument": map[string]interface{}{"uri": testURI},
+		}},
+		map[string]interface{}{"jsonrpc": "2.0", "id": 6, "method": "unknown/method"},
+		map[string]interface{}{"jsonrpc": "2.0", "id": 7, "method": "shutdown"},
+		map[string]interface{}{"jsonrpc": "2.0", "method": "exit"},
+	}
+
+	in := &bytes.Buffer{}
+	for _, request := range requests {
+		content, _ := json.Marshal(request)
+		fmt.Fprintf(in, "Content-Length: %d\r\n\r\n%s", len(content), content)
+	}
+
+	out := &bytes.Buffer{}
+	server := lsp.New(docChecker, docParser, formatter.New(), "test")
+	if err := server.Serve(in, out); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	messages := readMessages(t, out)
+	if len(messages) != 8 {
+		t.Fatalf("expected 8 messages, got %d", len(messages))
+	}
+
+	testCases := []struct {
+		name     string
+		message  map[string]interface{}
+		path     []string
+		expected interface{}
+	}{
+		{
+			name:     "initialize",
+			message:  messages[0],
+			path:     []string{"result", "capabilities", "hoverProvider"},
+			expected: true,
+		},
+		{
+			name:     "diagnostics",
+			message:  messages[1],
+			path:     []string{"params", "diagnostics"},
+			expected: []interface{}{diagnostic(2, 4, 2, 6, "variable id is declared but not used")},
+		},
+		{
+			name:     "hover",
+			message:  messages[2],
+			path:     []string{"result", "contents", "value"},
+			expected: "**id** = `1`",
+		},
+		{
+			name:     "definition",
+			message:  messages[3],
+			path:     []string{"result", "range"},
+			expected: rangeOf(2, 4, 2, 6),
+		},
+		{
+			name:     "completion",
+			message:  messages[4],
+			path:     []string{"result"},
+			expected: []string{"base", "id", "method", "url", "params", "query", "headers", "body", "auth", "env", "file", "date", "uuid", "store"},
+		},
+		{
+			name:     "formatting",
+			message:  messages[5],
+			path:     []string{"result"},
+			expected: []interface{}{map[string]interface{}{"range": rangeOf(0, 0, 10, 0), "newText": strings.Replace(testText, "  method", "    method", 1)}},
+		},
+		{
+			name:     "method not found",
+			message:  messages[6],
+			path:     []string{"error", "code"},
+			expected: float64(-32601),
+		},
+		{
+			name:     "shutdown",
+			message:  messages[7],
+			path:     []string{"result"},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var value interface{} = tc.message
+			for _, key := range tc.path {
+				value = value.(map[string]interface{})[key]
+			}
+
+			if tc.name == "completion" {
+				value = completionLabelsFromResult(value)
+			}
+
+			if !reflect.DeepEqual(value, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, value)
+			}
+		})
+	}
+}
+
+func TestServer_Serve_ParseError(t *testing.T) {
+	in := &bytes.Buffer{}
+	for _, content := range []string{
+		`{"jsonrpc": "2.0", "id": 1, "method": `,
+		`{"jsonrpc": "2.0", "id": 2, "method": "shutdown"}`,
+		`{"jsonrpc": "2.0", "method": "exit"}`,
+	} {
+		fmt.Fprintf(in, "Content-Length: %d\r\n\r\n%s", len(content), content)
+	}
+
+	out := &bytes.Buffer{}
+	server := lsp.New(&checker.Mock{}, &parser.Mock{}, formatter.New(), "test")
+	if err := server.Serve(in, out); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	messages := readMessages(t, out)
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(messages))
+	}
+
+	if id, ok := messages[0]["id"]; !ok || id != nil {
+		t.Errorf("expected a null id, got %v", id)
+	}
+	if code := messages[0]["error"].(map[string]interface{})["code"]; code != float64(-32700) {
+		t.Errorf("expected error code -32700, got %v", code)
+	}
+	if id := messages[1]["id"]; id != float64(2) {
+		t.Errorf("expected the response of the next request, got %v", messages[1])
+	}
+}
+
+func position(line, character int) map[string]interface{} {
+	return map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": testURI},
+		"position":     map[string]interface{}{"line": line, "character": character},
+	}
+}
+
+func rangeOf(startLine, startChar, endLine, endChar int) map[string]interface{} {
+	return map[string]interface{}{
+		"start": map[string]interface{}{"line": float64(startLine), "character": float64(startChar)},
+		"end":   map[string]interface{}{"line": float64(endLine), "character": float64(endChar)},
+	}
+}
+
+func diagnostic(startLine, startChar, endLine, endChar int, message string) map[string]interface{} {
+	return map[string]interface{}{
+		"range":    rangeOf(startLine, startChar, endLine, endChar),
+		"severity": float64(1),
+		"source":   "do-lsp",
+		"message":  message,
+	}
+}
+
+func completionLabelsFromResult(result interface{}) []string {
+	labels := make([]string, 0)
+	for _, item := range result.([]interface{}) {
+		labels = append(labels, item.(map[string]interface{})["label"].(string))
+	}
+
+	return labels
+}
+
+func readMessages(t *testing.T, out *bytes.Buffer) []map[string]interface{} {
+	messages := make([]map[string]interface{}, 0)
+	r := bufio.NewReader(out)
+
+	for {
+		header, err := r.ReadString('\n')
+		if err != nil {
+			return messages
+		}
+
+		length, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(header, "Content-Length:")))
+		if err != nil {
+			t.Fatalf("invalid header %q", header)
+		}
+
+		_, _ = r.ReadString('\n')
+		content := make([]byte, length)
+		if _, err = io.ReadFull(r, content); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		var message map[string]interface{}
+		if err = json.Unmarshal(content, &message); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		messages = append(messages, message)
+	}
+}
@@ -0,0 +1,13 @@
+package oauth2
+
+type CanNotGetTokenError struct {
+	reason string
+}
+
+func NewCanNotGetTokenError(reason string) error {
+	return CanNotGetTokenError{reason}
+}
+
+func (e CanNotGetTokenError) Error() string {
+	return "can not get oauth2 token: " + e.reason
+}
@@ -0,0 +1,20 @@
+package oauth2
+
+import (
+	"net/http"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+type Mock struct {
+	TokenFn      func(auth types.Auth) (string, error)
+	WithClientFn func(client *http.Client) TokenSource
+}
+
+func (m *Mock) Token(auth types.Auth) (string, error) {
+	return m.TokenFn(auth)
+}
+
+func (m *Mock) WithClient(client *http.Client) TokenSource {
+	return m.WithClientFn(client)
+}
@@ -0,0 +1,215 @@
+package oauth2
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+// expiryMargin is the time before the expiration when a cached token is not used anymore
+const expiryMargin = 30 * time.Second
+
+type TokenSource interface {
+	// Token returns the access token of the auth, from the cache when it is not expired.
+	Token(auth types.Auth) (string, error)
+	// WithClient returns a token source that requests the tokens with the client, sharing the cache.
+	WithClient(client *http.Client) TokenSource
+}
+
+// cachedToken defines the token kept in the cache directory
+type cachedToken struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// tokenResponse defines the response of the token endpoint
+type tokenResponse struct {
+	AccessToken      string      `json:"access_token"`
+	TokenType        string      `json:"token_type"`
+	ExpiresIn        json.Number `json:"expires_in"`
+	RefreshToken     string      `json:"refresh_token"`
+	Error            string      `json:"error"`
+	ErrorDescription string      `json:"error_description"`
+}
+
+type tokenSource struct {
+	client   *http.Client
+	cacheDir string
+	now      func() time.Time
+}
+
+// New returns a token source that requests the tokens with the client and keeps them in the
+// cache directory until they expire. The tokens are not cached when the directory is empty.
+func New(client *http.Client, cacheDir string) TokenSource {
+	return &tokenSource{client: client, cacheDir: cacheDir, now: time.Now}
+}
+
+// CacheDir returns the directory of the cached tokens inside the cache directory of the user
+func CacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "do", "oauth2")
+}
+
+func (s *tokenSource) WithClient(client *http.Client) TokenSource {
+	withClient := *s
+	withClient.client = client
+	return &withClient
+}
+
+func (s *tokenSource) Token(auth types.Auth) (string, error) {
+	path := s.cachePath(auth)
+
+	cached, ok := s.load(path)
+	if ok && s.now().Add(expiryMargin).Before(cached.ExpiresAt) {
+		return cached.AccessToken, nil
+	}
+
+	var token *cachedToken
+	var err error
+	if ok && cached.RefreshToken != "" {
+		// the grant of the auth is used when the refresh token was revoked
+		refresh := auth
+		refresh.GrantType, refresh.RefreshToken = types.GrantRefreshToken, cached.RefreshToken
+		token, err = s.request(refresh)
+	}
+
+	if token == nil {
+		if token, err = s.request(auth); err != nil {
+			return "", err
+		}
+	}
+
+	if token.RefreshToken == "" && ok {
+		token.RefreshToken = cached.RefreshToken
+	}
+	s.save(path, token)
+
+	return token.AccessToken, nil
+}
+
+// request gets a new token from the token endpoint
+func (s *tokenSource) request(auth types.Auth) (*cachedToken, error) {
+	form := url.Values{"grant_type": {auth.GrantType}}
+	switch auth.GrantType {
+	case types.GrantPassword:
+		form.Set("username", auth.Username)
+		form.Set("password", auth.Password)
+	case types.GrantRefreshToken:
+		form.Set("refresh_token", auth.RefreshToken)
+	}
+	if auth.Scope != "" {
+		form.Set("scope", auth.Scope)
+	}
+	if auth.ClientSecret == "" {
+		// public clients identify themselves in the body
+		form.Set("client_id", auth.ClientID)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, auth.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, NewCanNotGetTokenError(err.Error())
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if auth.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(auth.ClientID), url.QueryEscape(auth.ClientSecret))
+	}
+
+	requestedAt := s.now()
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, NewCanNotGetTokenError(err.Error())
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, NewCanNotGetTokenError(err.Error())
+	}
+
+	var response tokenResponse
+	if err = json.Unmarshal(body, &response); err != nil {
+		return nil, NewCanNotGetTokenError(fmt.Sprintf("invalid response %d: %s", res.StatusCode, string(body)))
+	}
+
+	if res.StatusCode != http.StatusOK || response.AccessToken == "" {
+		reason := strings.TrimSpace(response.Error + " " + response.ErrorDescription)
+		if reason == "" {
+			reason = "no access_token"
+		}
+		return nil, NewCanNotGetTokenError(fmt.Sprintf("%d %s", res.StatusCode, reason))
+	}
+
+	token := &cachedToken{AccessToken: response.AccessToken, RefreshToken: response.RefreshToken}
+	if seconds, err := response.ExpiresIn.Int64(); err == nil && seconds > 0 {
+		token.ExpiresAt = requestedAt.Add(time.Duration(seconds) * time.Second)
+	}
+
+	return token, nil
+}
+
+// cachePath returns the file of the cached token, identified by the endpoint, the client and the user.
+// The credentials are part of the hashed key, so a token is not reused after they change.
+func (s *tokenSource) cachePath(auth types.Auth) string {
+	if s.cacheDir == "" {
+		return ""
+	}
+
+	key := strings.Join([]string{
+		auth.TokenURL, auth.ClientID, auth.GrantType, auth.Scope, auth.Username,
+		auth.ClientSecret, auth.Password, auth.RefreshToken,
+	}, "\n")
+	sum := sha256.Sum256([]byte(key))
+
+	return filepath.Join(s.cacheDir, hex.EncodeToString(sum[:16])+".json")
+}
+
+func (s *tokenSource) load(path string) (*cachedToken, bool) {
+	if path == "" {
+		return nil, false
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var token cachedToken
+	if err = json.Unmarshal(content, &token); err != nil {
+		return nil, false
+	}
+
+	return &token, true
+}
+
+// save writes the token to the cache, a token without expiration is not cached
+func (s *tokenSource) save(path string, token *cachedToken) {
+	if path == "" || token.ExpiresAt.IsZero() {
+		return
+	}
+
+	content, err := json.Marshal(token)
+	if err != nil {
+		return
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, content, 0600)
+}
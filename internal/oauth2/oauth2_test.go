@@ -0,0 +1,200 @@
+package oauth2_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/jibaru/do/internal/oauth2"
+	"github.com/jibaru/do/internal/types"
+)
+
+func TestTokenSource_Token(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		grant := r.PostForm.Get("grant_type")
+		requests = append(requests, grant)
+
+		clientID, clientSecret, _ := r.BasicAuth()
+		if clientID == "" {
+			clientID = r.PostForm.Get("client_id")
+		}
+
+		response := map[string]interface{}{"token_type": "Bearer", "expires_in": 3600}
+		switch {
+		case clientID == "expiring":
+			response["access_token"] = "short-" + grant
+			response["expires_in"] = 10
+			response["refresh_token"] = "refresh"
+		case grant == types.GrantClientCredentials && clientSecret == "secret":
+			response["access_token"] = "client-" + r.PostForm.Get("scope")
+		case grant == types.GrantPassword && r.PostForm.Get("username") == "john" && r.PostForm.Get("password") == "do":
+			response["access_token"] = "password-" + clientID
+		case grant == types.GrantRefreshToken && r.PostForm.Get("refresh_token") == "refresh":
+			response["access_token"] = "refreshed"
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			response = map[string]interface{}{"error": "invalid_grant", "error_description": "bad credentials"}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name             string
+		auth             types.Auth
+		calls            int
+		expected         string
+		expectedRequests []string
+		expectedError    error
+	}{
+		{
+			name: "success client credentials is cached",
+			auth: types.Auth{
+				Type:         types.AuthOAuth2,
+				GrantType:    types.GrantClientCredentials,
+				TokenURL:     server.URL,
+				ClientID:     "app",
+				ClientSecret: "secret",
+				Scope:        "read",
+			},
+			calls:            2,
+			expected:         "client-read",
+			expectedRequests: []string{types.GrantClientCredentials},
+		},
+		{
+			name: "success password with public client",
+			auth: types.Auth{
+				Type:      types.AuthOAuth2,
+				GrantType: types.GrantPassword,
+				TokenURL:  server.URL,
+				ClientID:  "cli",
+				Username:  "john",
+				Password:  "do",
+			},
+			calls:            1,
+			expected:         "password-cli",
+			expectedRequests: []string{types.GrantPassword},
+		},
+		{
+			name: "success refresh token",
+			auth: types.Auth{
+				Type:         types.AuthOAuth2,
+				GrantType:    types.GrantRefreshToken,
+				TokenURL:     server.URL,
+				ClientID:     "app",
+				RefreshToken: "refresh",
+			},
+			calls:            1,
+			expected:         "refreshed",
+			expectedRequests: []string{types.GrantRefreshToken},
+		},
+		{
+			name: "success token close to expire is refreshed",
+			auth: types.Auth{
+				Type:      types.AuthOAuth2,
+				GrantType: types.GrantClientCredentials,
+				TokenURL:  server.URL,
+				ClientID:  "expiring",
+			},
+			calls:            2,
+			expected:         "short-" + types.GrantRefreshToken,
+			expectedRequests: []string{types.GrantClientCredentials, types.GrantRefreshToken},
+		},
+		{
+			name: "error invalid credentials",
+			auth: types.Auth{
+				Type:         types.AuthOAuth2,
+				GrantType:    types.GrantClientCredentials,
+				TokenURL:     server.URL,
+				ClientID:     "app",
+				ClientSecret: "wrong",
+			},
+			calls:            1,
+			expectedRequests: []string{types.GrantClientCredentials},
+			expectedError:    errors.New("can not get oauth2 token: 400 invalid_grant bad credentials"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requests = nil
+			source := oauth2.New(server.Client(), t.TempDir())
+
+			var token string
+			var err error
+			for i := 0; i < tc.calls; i++ {
+				token, err = source.Token(tc.auth)
+			}
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if token != tc.expected {
+				t.Errorf("expected token %q, got %q", tc.expected, token)
+			}
+
+			if len(requests) != len(tc.expectedRequests) {
+				t.Fatalf("expected requests %v, got %v", tc.expectedRequests, requests)
+			}
+			for i := range requests {
+				if requests[i] != tc.expectedRequests[i] {
+					t.Errorf("expected requests %v, got %v", tc.expectedRequests, requests)
+				}
+			}
+		})
+	}
+}
+
+func TestTokenSource_Token_SharedCache(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"access_token":"abc","expires_in":"3600"}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	auth := types.Auth{Type: types.AuthOAuth2, GrantType: types.GrantClientCredentials, TokenURL: server.URL, ClientID: "app"}
+
+	for i := 0; i < 2; i++ {
+		// each run of do creates a new token source
+		token, err := oauth2.New(server.Client(), dir).Token(auth)
+		if err != nil || token != "abc" {
+			t.Fatalf("expected token abc, got %q %v", token, err)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("expected 1 request to the token endpoint, got %d", calls)
+	}
+
+	// a rotated secret does not reuse the token of the previous one
+	auth.ClientSecret = "rotated"
+	if _, err := oauth2.New(server.Client(), dir).Token(auth); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 requests to the token endpoint, got %d", calls)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 cached tokens, got %d", len(entries))
+	}
+	info, _ := entries[0].Info()
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected the cached token to be private, got %v", info.Mode().Perm())
+	}
+}
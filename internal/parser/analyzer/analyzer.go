@@ -103,10 +103,7 @@ func isFunc(value string) bool {
 		return false
 	}
 
-	return matches[1] == types.EnvFuncName ||
-		matches[1] == types.FileFuncName ||
-		matches[1] == types.UuidFuncName ||
-		matches[1] == types.DateFuncName
+	return types.IsFuncName(matches[1])
 }
 
 func isBool(value string) bool {
@@ -133,11 +130,11 @@ func isFloat(value string) (float64, bool) {
 
 func toMap(value string) (types.Map, error) {
 	value = strings.TrimSpace(value[1 : len(value)-1]) // Remove the curly braces
-	parts := strings.Split(value, ",")
+	parts := splitOutsideStrings(value, ',', -1)
 	result := make(map[string]interface{})
 
 	for _, part := range parts {
-		pair := strings.SplitN(part, ":", 2)
+		pair := splitOutsideStrings(part, ':', 2)
 		if len(pair) != 2 {
 			return nil, NewInvalidValueError(part)
 		}
@@ -179,6 +176,38 @@ func toMap(value string) (types.Map, error) {
 	return types.Map(result), nil
 }
 
+// splitOutsideStrings splits the value by the separator, ignoring the separators
+// inside strings and nested maps. n works as in strings.SplitN.
+func splitOutsideStrings(value string, separator rune, n int) []string {
+	parts := make([]string, 0)
+	current := strings.Builder{}
+	inQuotes := false
+	inBackticks := false
+	depth := 0
+
+	for _, ch := range value {
+		switch {
+		case ch == '"' && !inBackticks:
+			inQuotes = !inQuotes
+		case ch == '`' && !inQuotes:
+			inBackticks = !inBackticks
+		case inQuotes || inBackticks:
+		case ch == '{':
+			depth++
+		case ch == '}':
+			depth--
+		case ch == separator && depth == 0 && (n < 0 || len(parts) < n-1):
+			parts = append(parts, current.String())
+			current.Reset()
+			continue
+		}
+
+		current.WriteRune(ch)
+	}
+
+	return append(parts, current.String())
+}
+
 func isReferenceToVariable(value string) bool {
 	for i, char := range value {
 		if i == 0 && unicode.IsDigit(char) {
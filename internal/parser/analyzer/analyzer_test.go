@@ -94,6 +94,25 @@ func TestAnalyzer_Analyze(t *testing.T) {
 				},
 			}),
 		},
+		{
+			name: "success map with separators in strings and nested maps",
+			expressions: types.SectionExpressions{
+				"var1={\"Accept\": \"text/html, application/json\", \"a:b\": `x, y: z`, \"nested\": {\"a\": 1, \"b\": 2}}",
+			},
+			expected: types.NewSentencesFromSlice([]types.Sentence{
+				{
+					Key: "var1",
+					Value: types.Map{
+						"Accept": types.String("text/html, application/json"),
+						"a:b":    types.String("x, y: z"),
+						"nested": types.Map{
+							"a": types.Int(1),
+							"b": types.Int(2),
+						},
+					},
+				},
+			}),
+		},
 		/*{
 			// TODO: make sure this test is passing
 			name: "success map with call",
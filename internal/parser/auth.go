@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+// authFields defines the fields required by each type of auth
+var authFields = map[string][]string{
+	types.AuthBasic:  {"username", "password"},
+	types.AuthBearer: {"token"},
+	types.AuthDigest: {"username", "password"},
+	types.AuthOAuth2: {"token_url", "client_id"},
+	types.AuthSigV4:  {"access_key", "secret_key", "region", "service"},
+}
+
+// grantFields defines the fields required by each grant type of oauth2
+var grantFields = map[string][]string{
+	types.GrantClientCredentials: {},
+	types.GrantPassword:          {"username", "password"},
+	types.GrantRefreshToken:      {"refresh_token"},
+}
+
+// toAuth returns the auth defined by a map like {"type": "basic", "username": "john", "password": "secret"}
+func toAuth(value interface{}) (*types.Auth, error) {
+	mp, ok := value.(types.Map)
+	if !ok || !mp.HasStringValues() {
+		return nil, NewTypeNotExpectedError(types.DoAuth, "types.Map[string]string", fmt.Sprintf("%T", value))
+	}
+
+	authType, _ := mp["type"].(types.String)
+	auth := &types.Auth{Type: string(authType)}
+	required, ok := authFields[auth.Type]
+	if auth.Type == "" {
+		return nil, NewInvalidAuthError("type is required")
+	} else if !ok {
+		return nil, NewInvalidAuthError(fmt.Sprintf("unknown type %q, use basic, bearer, digest, oauth2 or sigv4", auth.Type))
+	}
+
+	fields := map[string]*string{
+		"username":      &auth.Username,
+		"password":      &auth.Password,
+		"token":         &auth.Token,
+		"grant_type":    &auth.GrantType,
+		"token_url":     &auth.TokenURL,
+		"client_id":     &auth.ClientID,
+		"client_secret": &auth.ClientSecret,
+		"scope":         &auth.Scope,
+		"refresh_token": &auth.RefreshToken,
+		"access_key":    &auth.AccessKey,
+		"secret_key":    &auth.SecretKey,
+		"session_token": &auth.SessionToken,
+		"region":        &auth.Region,
+		"service":       &auth.Service,
+	}
+
+	for key, val := range mp {
+		if key == "type" {
+			continue
+		}
+
+		field, ok := fields[key]
+		if !ok {
+			return nil, NewInvalidAuthError("unknown field " + key)
+		}
+		*field = string(val.(types.String))
+	}
+
+	if auth.Type == types.AuthOAuth2 {
+		if auth.GrantType == "" {
+			auth.GrantType = types.GrantClientCredentials
+		}
+
+		grant, ok := grantFields[auth.GrantType]
+		if !ok {
+			return nil, NewInvalidAuthError(fmt.Sprintf("unknown grant_type %q, use client_credentials, password or refresh_token", auth.GrantType))
+		}
+		required = append(required, grant...)
+	}
+
+	for _, key := range required {
+		if _, ok := mp[key]; !ok {
+			return nil, NewInvalidAuthError(key + " is required for " + auth.Type)
+		}
+	}
+
+	return auth, nil
+}
@@ -14,15 +14,18 @@ type Caller interface {
 type caller struct {
 	uuidFactory utils.UuidFactory
 	dateFactory utils.DateFactory
+	storeReader types.StoreReader
 }
 
 func New(
 	uuidFactory utils.UuidFactory,
 	dateFactory utils.DateFactory,
+	storeReader types.StoreReader,
 ) Caller {
 	return &caller{
 		uuidFactory,
 		dateFactory,
+		storeReader,
 	}
 }
 
@@ -39,6 +42,7 @@ func (c *caller) Call(variables map[string]interface{}) error {
 			resolvedFn, err := fn.Resolve(
 				c.uuidFactory,
 				c.dateFactory,
+				c.storeReader,
 			)
 			if err != nil {
 				return err
@@ -57,6 +61,12 @@ func (c *caller) Call(variables map[string]interface{}) error {
 			case types.DateFunc:
 				dateFunc := resolvedFn.(types.DateFunc)
 				variables[name] = dateFunc.Exec()
+			case types.StoreFunc:
+				value, err := resolvedFn.(types.StoreFunc).Exec()
+				if err != nil {
+					return err
+				}
+				variables[name] = value
 			}
 		}
 	}
@@ -62,6 +62,7 @@ func TestCaller_Call(t *testing.T) {
 			c := caller.New(
 				utils.NewFixedUuidFactory(uuid),
 				utils.NewFixedDateFactory(now),
+				nil,
 			)
 			err := c.Call(tc.variables)
 			if err != nil && tc.expectedError == nil {
@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/jibaru/do/internal/jsonpath"
+	"github.com/jibaru/do/internal/types"
+)
+
+// toCaptures returns the captures defined by the sentences of the capture section, in order.
+// Each value is a string like "json:$.data.token", "header:X-Request-Id", "cookie:sid",
+// "regex:id=(\d+)", "status" or "body".
+func toCaptures(sentences *types.Sentences) ([]types.Capture, error) {
+	captures := make([]types.Capture, 0, len(sentences.Entries()))
+
+	for _, sentence := range sentences.Entries() {
+		value, ok := sentence.Value.(types.String)
+		if !ok {
+			return nil, NewInvalidCaptureError(sentence.Key, `expected a string like "json:$.id"`)
+		}
+
+		source, expression, _ := strings.Cut(string(value), ":")
+		capture := types.Capture{Name: sentence.Key, Source: source, Expression: expression}
+
+		switch source {
+		case types.CaptureStatus, types.CaptureBody:
+			if expression != "" {
+				return nil, NewInvalidCaptureError(sentence.Key, source+" does not have an expression")
+			}
+		case types.CaptureHeader, types.CaptureCookie:
+			if expression = strings.TrimSpace(expression); expression == "" {
+				return nil, NewInvalidCaptureError(sentence.Key, "the "+source+" name is required")
+			}
+			capture.Expression = expression
+		case types.CaptureJSON:
+			if _, err := jsonpath.Parse(expression); err != nil {
+				return nil, NewInvalidCaptureError(sentence.Key, err.Error())
+			}
+		case types.CaptureRegex:
+			if _, err := regexp.Compile(expression); err != nil {
+				return nil, NewInvalidCaptureError(sentence.Key, err.Error())
+			}
+		default:
+			return nil, NewInvalidCaptureError(sentence.Key, "unknown source "+source+", use json, header, cookie, regex, status or body")
+		}
+
+		captures = append(captures, capture)
+	}
+
+	return captures, nil
+}
@@ -44,3 +44,79 @@ func (e URLRequiredError) Error() string {
 func (e TypeNotExpectedError) Error() string {
 	return "type not expected for key: " + e.Key + ", expected: " + e.Expected + ", actual: " + e.Actual
 }
+
+type InvalidOptionError struct {
+	Key    string
+	Reason string
+}
+
+func NewInvalidOptionError(key, reason string) error {
+	return InvalidOptionError{
+		Key:    key,
+		Reason: reason,
+	}
+}
+
+func (e InvalidOptionError) Error() string {
+	return "invalid option " + e.Key + ": " + e.Reason
+}
+
+type InvalidAuthError struct {
+	Reason string
+}
+
+func NewInvalidAuthError(reason string) error {
+	return InvalidAuthError{Reason: reason}
+}
+
+func (e InvalidAuthError) Error() string {
+	return "invalid auth: " + e.Reason
+}
+
+type InvalidCaptureError struct {
+	Key    string
+	Reason string
+}
+
+type InvalidExpectationError struct {
+	Key    string
+	Reason string
+}
+
+type InvalidSnapshotError struct {
+	Key    string
+	Reason string
+}
+
+func NewInvalidCaptureError(key, reason string) error {
+	return InvalidCaptureError{
+		Key:    key,
+		Reason: reason,
+	}
+}
+
+func NewInvalidExpectationError(key, reason string) error {
+	return InvalidExpectationError{
+		Key:    key,
+		Reason: reason,
+	}
+}
+
+func NewInvalidSnapshotError(key, reason string) error {
+	return InvalidSnapshotError{
+		Key:    key,
+		Reason: reason,
+	}
+}
+
+func (e InvalidCaptureError) Error() string {
+	return "invalid capture " + e.Key + ": " + e.Reason
+}
+
+func (e InvalidExpectationError) Error() string {
+	return "invalid expectation " + e.Key + ": " + e.Reason
+}
+
+func (e InvalidSnapshotError) Error() string {
+	return "invalid snapshot " + e.Key + ": " + e.Reason
+}
@@ -0,0 +1,95 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/jibaru/do/internal/jsonpath"
+	"github.com/jibaru/do/internal/types"
+)
+
+// toExpectations returns the expectations defined by the sentences of the expect section, in order.
+// The headers, json, contains and matches maps give an expectation by key, sorted by the key.
+func toExpectations(sentences *types.Sentences) ([]types.Expectation, error) {
+	expectations := make([]types.Expectation, 0, len(sentences.Entries()))
+
+	for _, sentence := range sentences.Entries() {
+		key, value := sentence.Key, sentence.Value
+
+		switch key {
+		case types.ExpectStatus:
+			status, ok := value.(types.Int)
+			if !ok || status < 100 || status > 599 {
+				return nil, NewInvalidExpectationError(key, "expected a status code like 200")
+			}
+			expectations = append(expectations, types.Expectation{Type: key, Value: status})
+		case types.ExpectMaxTime:
+			duration, err := toDuration(key, value)
+			if err != nil {
+				return nil, NewInvalidExpectationError(key, fmt.Sprintf("expected a duration like \"500ms\", got %v", value))
+			}
+			expectations = append(expectations, types.Expectation{Type: key, Value: duration})
+		case types.ExpectMaxSize:
+			size, ok := value.(types.Int)
+			if !ok || size < 0 {
+				return nil, NewInvalidExpectationError(key, "expected a positive number of bytes")
+			}
+			expectations = append(expectations, types.Expectation{Type: key, Value: size})
+		case types.ExpectHeaders, types.ExpectJSON, types.ExpectContains, types.ExpectMatches:
+			mp, ok := value.(types.Map)
+			if !ok {
+				return nil, NewInvalidExpectationError(key, "expected a map")
+			}
+
+			targets := make([]string, 0, len(mp))
+			for target := range mp {
+				targets = append(targets, target)
+			}
+			sort.Strings(targets)
+
+			for _, target := range targets {
+				if err := validateExpectation(key, target, mp[target]); err != nil {
+					return nil, NewInvalidExpectationError(key, target+": "+err.Error())
+				}
+				expectations = append(expectations, types.Expectation{Type: key, Target: target, Value: mp[target]})
+			}
+		default:
+			return nil, NewInvalidExpectationError(key, "unknown expectation, use status, headers, json, contains, matches, max_time or max_size")
+		}
+	}
+
+	return expectations, nil
+}
+
+// validateExpectation returns an error when the target or the expected value of a map entry are not valid
+func validateExpectation(key, target string, value interface{}) error {
+	if key != types.ExpectHeaders {
+		if _, err := jsonpath.Parse(target); err != nil {
+			return err
+		}
+	}
+
+	switch key {
+	case types.ExpectHeaders:
+		if _, ok := value.(types.String); !ok {
+			return fmt.Errorf("expected a string")
+		}
+	case types.ExpectMatches:
+		pattern, ok := value.(types.String)
+		if !ok {
+			return fmt.Errorf("expected a regex string")
+		}
+		if _, err := regexp.Compile(string(pattern)); err != nil {
+			return err
+		}
+	default:
+		switch value.(type) {
+		case types.String, types.Int, types.Float, types.Bool, types.Map:
+		default:
+			return fmt.Errorf("expected a string, number, boolean or map")
+		}
+	}
+
+	return nil
+}
@@ -6,8 +6,18 @@ import (
 
 type Mock struct {
 	ParseFromFilenameFn func(filename string) (*types.DoFile, error)
+	ParseFromContentFn  func(content types.FileReaderContent) (*types.DoFile, error)
+	WithVariablesFn     func(variables types.Map) Parser
 }
 
 func (m *Mock) ParseFromFilename(filename string) (*types.DoFile, error) {
 	return m.ParseFromFilenameFn(filename)
 }
+
+func (m *Mock) ParseFromContent(content types.FileReaderContent) (*types.DoFile, error) {
+	return m.ParseFromContentFn(content)
+}
+
+func (m *Mock) WithVariables(variables types.Map) Parser {
+	return m.WithVariablesFn(variables)
+}
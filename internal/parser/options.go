@@ -0,0 +1,156 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+// toOptions returns the options defined by the variables of the options section
+func toOptions(variables map[string]interface{}) (types.Options, error) {
+	options := types.Options{}
+
+	for key, value := range variables {
+		var err error
+
+		switch key {
+		case types.OptionTimeout:
+			options.Timeout, err = toDuration(key, value)
+		case types.OptionConnectTimeout:
+			options.ConnectTimeout, err = toDuration(key, value)
+		case types.OptionTLSTimeout:
+			options.TLSTimeout, err = toDuration(key, value)
+		case types.OptionResponseTimeout:
+			options.ResponseTimeout, err = toDuration(key, value)
+		case types.OptionBackoff:
+			options.Backoff, err = toDuration(key, value)
+		case types.OptionMaxBackoff:
+			options.MaxBackoff, err = toDuration(key, value)
+		case types.OptionRetries:
+			retries, ok := value.(types.Int)
+			if !ok || retries < 0 {
+				return options, NewInvalidOptionError(key, "expected a positive integer")
+			}
+			options.Retries = int(retries)
+		case types.OptionRetryOn:
+			options.RetryOn, err = ParseStatusCodes(fmt.Sprintf("%v", value))
+			if err != nil {
+				return options, NewInvalidOptionError(key, err.Error())
+			}
+		case types.OptionRetryOnNetworkError:
+			options.RetryOnNetworkError, err = toBool(key, value)
+		case types.OptionFollowRedirects:
+			follow, boolErr := toBool(key, value)
+			options.FollowRedirects, err = &follow, boolErr
+		case types.OptionMaxRedirects:
+			redirects, ok := value.(types.Int)
+			if !ok || redirects < 0 {
+				return options, NewInvalidOptionError(key, "expected a positive integer")
+			}
+			options.MaxRedirects = int(redirects)
+		case types.OptionKeepAuthorization:
+			options.KeepAuthorization, err = toBool(key, value)
+		case types.OptionCACert:
+			options.CACert, err = toPath(key, value)
+		case types.OptionClientCert:
+			options.ClientCert, err = toPath(key, value)
+		case types.OptionClientKey:
+			options.ClientKey, err = toPath(key, value)
+		case types.OptionTLSMinVersion:
+			options.TLSMinVersion = fmt.Sprintf("%v", value)
+		case types.OptionTLSServerName:
+			options.TLSServerName, err = toString(key, value)
+		case types.OptionInsecure:
+			options.Insecure, err = toBool(key, value)
+		case types.OptionProxy:
+			options.Proxy, err = toString(key, value)
+		case types.OptionNoProxy:
+			options.NoProxy, err = toString(key, value)
+		case types.OptionCookieJar:
+			options.CookieJar, err = toPath(key, value)
+		case types.OptionSession:
+			options.Session, err = toString(key, value)
+		case types.OptionStoreTTL:
+			options.StoreTTL, err = toDuration(key, value)
+		default:
+			return options, NewInvalidOptionError(key, "unknown option")
+		}
+
+		if err != nil {
+			return options, err
+		}
+	}
+
+	return options, nil
+}
+
+// toPath returns the path of a file, given with the file function or as a string
+func toPath(key string, value interface{}) (string, error) {
+	switch val := value.(type) {
+	case types.File:
+		return val.Path, nil
+	case types.String:
+		return string(val), nil
+	}
+
+	return "", NewInvalidOptionError(key, "expected a file")
+}
+
+// toString returns the value when it is a string
+func toString(key string, value interface{}) (string, error) {
+	s, ok := value.(types.String)
+	if !ok {
+		return "", NewInvalidOptionError(key, "expected a string")
+	}
+
+	return string(s), nil
+}
+
+// toBool returns the value when it is a boolean
+func toBool(key string, value interface{}) (bool, error) {
+	b, ok := value.(types.Bool)
+	if !ok {
+		return false, NewInvalidOptionError(key, "expected a boolean")
+	}
+
+	return bool(b), nil
+}
+
+// toDuration returns the duration written as a string like "1.5s", or as an integer of milliseconds
+func toDuration(key string, value interface{}) (types.Duration, error) {
+	switch val := value.(type) {
+	case types.Int:
+		if val >= 0 {
+			return types.Duration(time.Duration(val) * time.Millisecond), nil
+		}
+	case types.String:
+		duration, err := time.ParseDuration(string(val))
+		if err == nil && duration >= 0 {
+			return types.Duration(duration), nil
+		}
+	}
+
+	return 0, NewInvalidOptionError(key, fmt.Sprintf("expected a duration like \"10s\", got %v", value))
+}
+
+// ParseStatusCodes returns the status codes of a comma separated list like "429,502,503"
+func ParseStatusCodes(list string) ([]int, error) {
+	codes := make([]int, 0)
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		code, err := strconv.Atoi(item)
+		if err != nil || code < 100 || code > 599 {
+			return nil, fmt.Errorf("invalid status code %q", item)
+		}
+		codes = append(codes, code)
+	}
+
+	return codes, nil
+}
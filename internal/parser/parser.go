@@ -15,6 +15,10 @@ import (
 
 type Parser interface {
 	ParseFromFilename(filename string) (*types.DoFile, error)
+	ParseFromContent(content types.FileReaderContent) (*types.DoFile, error)
+	// WithVariables returns a parser that adds the variables to the ones of the let section,
+	// replacing the let variables with the same name. They are the values captured by previous requests.
+	WithVariables(variables types.Map) Parser
 }
 
 type parser struct {
@@ -24,6 +28,7 @@ type parser struct {
 	variablesReplacer replacer.DoReplacer
 	funcCaller        caller.Caller
 	letResolver       resolver.LetResolver
+	variables         types.Map
 }
 
 func New(
@@ -41,15 +46,26 @@ func New(
 		variablesReplacer,
 		funcCaller,
 		letResolver,
+		nil,
 	}
 }
 
+func (p *parser) WithVariables(variables types.Map) Parser {
+	withVariables := *p
+	withVariables.variables = variables
+	return &withVariables
+}
+
 func (p *parser) ParseFromFilename(filename string) (*types.DoFile, error) {
 	content, err := p.doFileReader.Read(filename)
 	if err != nil {
 		return nil, err
 	}
 
+	return p.ParseFromContent(content)
+}
+
+func (p *parser) ParseFromContent(content types.FileReaderContent) (*types.DoFile, error) {
 	cleanedContent, err := p.commentCleaner.Clean(content)
 	if err != nil {
 		return nil, err
@@ -71,6 +87,34 @@ func (p *parser) ParseFromFilename(filename string) (*types.DoFile, error) {
 		return nil, NewDoSectionEmptyError()
 	}
 
+	optionSentences, err := p.sectionExtractor.Extract(types.OptionsSection, cleanedContent)
+	if err != nil {
+		if !errors.Is(err, extractor.ErrSectionExtractorNoBlock) {
+			return nil, err
+		}
+	}
+
+	captureSentences, err := p.sectionExtractor.Extract(types.CaptureSection, cleanedContent)
+	if err != nil {
+		if !errors.Is(err, extractor.ErrSectionExtractorNoBlock) {
+			return nil, err
+		}
+	}
+
+	expectSentences, err := p.sectionExtractor.Extract(types.ExpectSection, cleanedContent)
+	if err != nil {
+		if !errors.Is(err, extractor.ErrSectionExtractorNoBlock) {
+			return nil, err
+		}
+	}
+
+	snapshotSentences, err := p.sectionExtractor.Extract(types.SnapshotSection, cleanedContent)
+	if err != nil {
+		if !errors.Is(err, extractor.ErrSectionExtractorNoBlock) {
+			return nil, err
+		}
+	}
+
 	if !doSentences.Has(types.DoMethod) {
 		return nil, NewMethodRequiredError()
 	}
@@ -79,6 +123,8 @@ func (p *parser) ParseFromFilename(filename string) (*types.DoFile, error) {
 		return nil, NewURLRequiredError()
 	}
 
+	secrets := storeVariables(letSentences)
+
 	letSentences, err = p.letResolver.Resolve(letSentences)
 	if err != nil {
 		return nil, err
@@ -89,6 +135,17 @@ func (p *parser) ParseFromFilename(filename string) (*types.DoFile, error) {
 		letVariables = letSentences.ToMap()
 	}
 
+	if len(p.variables) > 0 {
+		if letVariables == nil {
+			letVariables = make(map[string]interface{}, len(p.variables))
+		}
+		for key, value := range p.variables {
+			letVariables[key] = value
+			// the captured values are kept in the store, so they are secrets too
+			secrets = append(secrets, key)
+		}
+	}
+
 	doVariables := doSentences.ToMap()
 	err = p.variablesReplacer.Replace(doVariables, letVariables)
 	if err != nil {
@@ -100,6 +157,37 @@ func (p *parser) ParseFromFilename(filename string) (*types.DoFile, error) {
 		return nil, err
 	}
 
+	options := types.Options{}
+	if optionSentences != nil {
+		optionVariables := optionSentences.ToMap()
+		if err = p.variablesReplacer.Replace(optionVariables, letVariables); err != nil {
+			return nil, err
+		}
+
+		if err = p.funcCaller.Call(optionVariables); err != nil {
+			return nil, err
+		}
+
+		if options, err = toOptions(optionVariables); err != nil {
+			return nil, err
+		}
+	}
+
+	if expectSentences != nil {
+		expectVariables := expectSentences.ToMap()
+		if err = p.variablesReplacer.Replace(expectVariables, letVariables); err != nil {
+			return nil, err
+		}
+
+		if err = p.funcCaller.Call(expectVariables); err != nil {
+			return nil, err
+		}
+
+		for key, value := range expectVariables {
+			expectSentences.Set(key, value)
+		}
+	}
+
 	if _, ok := doVariables[types.DoMethod].(types.String); !ok {
 		return nil, NewTypeNotExpectedError(
 			types.DoMethod,
@@ -152,11 +240,13 @@ func (p *parser) ParseFromFilename(filename string) (*types.DoFile, error) {
 	doFile := &types.DoFile{
 		Let: types.Let{
 			Variables: letVariables,
+			Secrets:   secrets,
 		},
 		Do: types.Do{
 			Method: doVariables[types.DoMethod].(types.String),
 			URL:    doVariables[types.DoURL].(types.String),
 		},
+		Options: options,
 	}
 
 	if mp, ok := doVariables[types.DoParams]; ok {
@@ -171,6 +261,30 @@ func (p *parser) ParseFromFilename(filename string) (*types.DoFile, error) {
 		doFile.Do.Headers = mp.(types.Map)
 	}
 
+	if value, ok := doVariables[types.DoAuth]; ok {
+		if doFile.Do.Auth, err = toAuth(value); err != nil {
+			return nil, err
+		}
+	}
+
+	if captureSentences != nil {
+		if doFile.Capture, err = toCaptures(captureSentences); err != nil {
+			return nil, err
+		}
+	}
+
+	if expectSentences != nil {
+		if doFile.Expect, err = toExpectations(expectSentences); err != nil {
+			return nil, err
+		}
+	}
+
+	if snapshotSentences != nil {
+		if doFile.Snapshot, err = toSnapshotConfig(snapshotSentences); err != nil {
+			return nil, err
+		}
+	}
+
 	if mp, ok := doVariables[types.DoBody]; ok {
 		switch mp.(type) {
 		case types.String:
@@ -188,3 +302,19 @@ func (p *parser) ParseFromFilename(filename string) (*types.DoFile, error) {
 
 	return doFile, nil
 }
+
+// storeVariables returns the names of the let variables read from the store
+func storeVariables(letSentences *types.Sentences) []string {
+	if letSentences == nil {
+		return nil
+	}
+
+	var names []string
+	for _, sentence := range letSentences.Entries() {
+		if fn, ok := sentence.Value.(types.Func); ok && fn.Name == types.StoreFuncName {
+			names = append(names, sentence.Key)
+		}
+	}
+
+	return names
+}
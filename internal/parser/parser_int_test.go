@@ -163,6 +163,65 @@ func TestParser_ParseFromFilename_Integration(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "07_options.do",
+			path: "examples/07_options.do",
+			expected: &types.DoFile{
+				Let: types.Let{
+					Variables: types.Map{
+						"retries": types.Int(2),
+						"ca":      types.File{Path: "certs/ca.pem"},
+					},
+				},
+				Do: types.Do{
+					Method: types.String("GET"),
+					URL:    types.String("http://localhost:8080/health"),
+				},
+				Options: types.Options{
+					Timeout:             types.Duration(30 * time.Second),
+					ConnectTimeout:      types.Duration(500 * time.Millisecond),
+					Retries:             2,
+					RetryOn:             []int{502, 503},
+					RetryOnNetworkError: true,
+					Backoff:             types.Duration(250 * time.Millisecond),
+					FollowRedirects:     utils.Ptr(true),
+					MaxRedirects:        3,
+					CACert:              "certs/ca.pem",
+					TLSMinVersion:       "1.2",
+					Proxy:               "socks5://localhost:1080",
+					NoProxy:             "*.internal",
+					Session:             "staging",
+				},
+			},
+		},
+		{
+			name: "08_expect.do",
+			path: "examples/08_expect.do",
+			expected: &types.DoFile{
+				Let: types.Let{
+					Variables: types.Map{
+						"id": types.Int(1),
+					},
+				},
+				Do: types.Do{
+					Method: types.String("GET"),
+					URL:    types.String("https://jsonplaceholder.typicode.com/todos/:id"),
+					Params: types.Map{
+						"id": types.Int(1),
+					},
+				},
+				Expect: []types.Expectation{
+					{Type: types.ExpectStatus, Value: types.Int(200)},
+					{Type: types.ExpectHeaders, Target: "Content-Type", Value: types.String("application/json; charset=utf-8")},
+					{Type: types.ExpectJSON, Target: "$.completed", Value: types.Bool(false)},
+					{Type: types.ExpectJSON, Target: "$.id", Value: types.Int(1)},
+					{Type: types.ExpectContains, Target: "$.title", Value: types.String("delectus")},
+					{Type: types.ExpectMatches, Target: "$.title", Value: types.String("^[a-z ]+$")},
+					{Type: types.ExpectMaxTime, Value: types.Duration(2 * time.Second)},
+					{Type: types.ExpectMaxSize, Value: types.Int(1024)},
+				},
+			},
+		},
 	}
 
 	uuidFactory := utils.NewFixedUuidFactory(uuid)
@@ -176,8 +235,8 @@ func TestParser_ParseFromFilename_Integration(t *testing.T) {
 	expressionAnalyzer := analyzer.New()
 	sectionExtractor := extractor.New(sectionTaker, sectionNormalizer, sectionPartitioner, expressionAnalyzer)
 	variablesReplacer := replacer.New()
-	funcCaller := caller.New(uuidFactory, dateFactory)
-	letResolver := resolver.NewLetResolver(uuidFactory, dateFactory)
+	funcCaller := caller.New(uuidFactory, dateFactory, nil)
+	letResolver := resolver.NewLetResolver(uuidFactory, dateFactory, nil)
 	theParser := parser.New(doFileReader, commentCleaner, sectionExtractor, variablesReplacer, funcCaller, letResolver)
 
 	for _, tc := range testCases {
@@ -196,6 +255,10 @@ func TestParser_ParseFromFilename_Integration(t *testing.T) {
 			} else if doFile == nil && tc.expected != nil {
 				t.Errorf("expected %v, got nil", tc.expected)
 			} else if doFile != nil && tc.expected != nil {
+				if !reflect.DeepEqual(doFile.Options, tc.expected.Options) {
+					t.Errorf("expected options %+v, got %+v", tc.expected.Options, doFile.Options)
+				}
+
 				if doFile.Let.Variables != nil && tc.expected.Let.Variables == nil {
 					for k, v := range doFile.Let.Variables {
 						expectedVal, ok := tc.expected.Let.Variables[k]
@@ -1,8 +1,10 @@
 package parser_test
 
 import (
+	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/jibaru/do/internal/parser"
 	"github.com/jibaru/do/internal/parser/caller"
@@ -18,6 +20,7 @@ func TestParser_FromFilename(t *testing.T) {
 	testCases := []struct {
 		name          string
 		filename      string
+		variables     types.Map
 		expected      *types.DoFile
 		expectedError error
 		FileReaderFn  func(filename string) (types.FileReaderContent, error)
@@ -53,6 +56,526 @@ func TestParser_FromFilename(t *testing.T) {
 					}), nil
 				}
 
+				return nil, nil
+			},
+		},
+		{
+			name:     "success auth",
+			filename: "auth.do",
+			expected: &types.DoFile{
+				Do: types.Do{
+					Method: types.String("GET"),
+					URL:    types.String("http://localhost:8080"),
+					Auth:   &types.Auth{Type: types.AuthBearer, Token: "token"},
+				},
+			},
+			ExtractorFn: func(section types.Section, content types.CleanedContent) (*types.Sentences, error) {
+				if section == types.DoSection {
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "method",
+							Value: types.String("GET"),
+						},
+						{
+							Key:   "url",
+							Value: types.String("http://localhost:8080"),
+						},
+						{
+							Key:   "auth",
+							Value: types.Map{"type": types.String("bearer"), "token": types.String("token")},
+						},
+					}), nil
+				}
+
+				return nil, nil
+			},
+		},
+		{
+			name:          "error auth without password",
+			filename:      "auth.do",
+			expectedError: errors.New("invalid auth: password is required for digest"),
+			ExtractorFn: func(section types.Section, content types.CleanedContent) (*types.Sentences, error) {
+				if section == types.DoSection {
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "method",
+							Value: types.String("GET"),
+						},
+						{
+							Key:   "url",
+							Value: types.String("http://localhost:8080"),
+						},
+						{
+							Key:   "auth",
+							Value: types.Map{"type": types.String("digest"), "username": types.String("john")},
+						},
+					}), nil
+				}
+
+				return nil, nil
+			},
+		},
+		{
+			name:     "success oauth2 auth with default grant",
+			filename: "auth.do",
+			expected: &types.DoFile{
+				Do: types.Do{
+					Method: types.String("GET"),
+					URL:    types.String("http://localhost:8080"),
+					Auth: &types.Auth{
+						Type:         types.AuthOAuth2,
+						GrantType:    types.GrantClientCredentials,
+						TokenURL:     "http://localhost:8080/token",
+						ClientID:     "app",
+						ClientSecret: "secret",
+						Scope:        "read write",
+					},
+				},
+			},
+			ExtractorFn: func(section types.Section, content types.CleanedContent) (*types.Sentences, error) {
+				if section == types.DoSection {
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "method",
+							Value: types.String("GET"),
+						},
+						{
+							Key:   "url",
+							Value: types.String("http://localhost:8080"),
+						},
+						{
+							Key: "auth",
+							Value: types.Map{
+								"type":          types.String("oauth2"),
+								"token_url":     types.String("http://localhost:8080/token"),
+								"client_id":     types.String("app"),
+								"client_secret": types.String("secret"),
+								"scope":         types.String("read write"),
+							},
+						},
+					}), nil
+				}
+
+				return nil, nil
+			},
+		},
+		{
+			name:          "error oauth2 password grant without password",
+			filename:      "auth.do",
+			expectedError: errors.New("invalid auth: password is required for oauth2"),
+			ExtractorFn: func(section types.Section, content types.CleanedContent) (*types.Sentences, error) {
+				if section == types.DoSection {
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "method",
+							Value: types.String("GET"),
+						},
+						{
+							Key:   "url",
+							Value: types.String("http://localhost:8080"),
+						},
+						{
+							Key: "auth",
+							Value: types.Map{
+								"type":       types.String("oauth2"),
+								"grant_type": types.String("password"),
+								"token_url":  types.String("http://localhost:8080/token"),
+								"client_id":  types.String("app"),
+								"username":   types.String("john"),
+							},
+						},
+					}), nil
+				}
+
+				return nil, nil
+			},
+		},
+		{
+			name:          "error sigv4 auth without region",
+			filename:      "auth.do",
+			expectedError: errors.New("invalid auth: region is required for sigv4"),
+			ExtractorFn: func(section types.Section, content types.CleanedContent) (*types.Sentences, error) {
+				if section == types.DoSection {
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "method",
+							Value: types.String("GET"),
+						},
+						{
+							Key:   "url",
+							Value: types.String("http://localhost:8080"),
+						},
+						{
+							Key: "auth",
+							Value: types.Map{
+								"type":       types.String("sigv4"),
+								"access_key": types.String("AKID"),
+								"secret_key": types.String("secret"),
+								"service":    types.String("s3"),
+							},
+						},
+					}), nil
+				}
+
+				return nil, nil
+			},
+		},
+		{
+			name:     "success capture",
+			filename: "capture.do",
+			expected: &types.DoFile{
+				Do: types.Do{
+					Method: types.String("GET"),
+					URL:    types.String("http://localhost:8080"),
+				},
+				Capture: []types.Capture{
+					{Name: "token", Source: types.CaptureJSON, Expression: "$.data.token"},
+					{Name: "requestId", Source: types.CaptureHeader, Expression: "X-Request-Id"},
+					{Name: "code", Source: types.CaptureStatus},
+				},
+			},
+			ExtractorFn: func(section types.Section, content types.CleanedContent) (*types.Sentences, error) {
+				switch section {
+				case types.DoSection:
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "method",
+							Value: types.String("GET"),
+						},
+						{
+							Key:   "url",
+							Value: types.String("http://localhost:8080"),
+						},
+					}), nil
+				case types.CaptureSection:
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "token",
+							Value: types.String("json:$.data.token"),
+						},
+						{
+							Key:   "requestId",
+							Value: types.String("header: X-Request-Id"),
+						},
+						{
+							Key:   "code",
+							Value: types.String("status"),
+						},
+					}), nil
+				}
+
+				return nil, nil
+			},
+		},
+		{
+			name:          "error capture unknown source",
+			filename:      "capture.do",
+			expectedError: errors.New("invalid capture token: unknown source xml, use json, header, cookie, regex, status or body"),
+			ExtractorFn: func(section types.Section, content types.CleanedContent) (*types.Sentences, error) {
+				switch section {
+				case types.DoSection:
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "method",
+							Value: types.String("GET"),
+						},
+						{
+							Key:   "url",
+							Value: types.String("http://localhost:8080"),
+						},
+					}), nil
+				case types.CaptureSection:
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "token",
+							Value: types.String("xml://token"),
+						},
+					}), nil
+				}
+
+				return nil, nil
+			},
+		},
+		{
+			name:     "success expect",
+			filename: "expect.do",
+			expected: &types.DoFile{
+				Do: types.Do{
+					Method: types.String("GET"),
+					URL:    types.String("http://localhost:8080"),
+				},
+				Expect: []types.Expectation{
+					{Type: types.ExpectStatus, Value: types.Int(200)},
+					{Type: types.ExpectJSON, Target: "$.data.id", Value: types.Int(12)},
+					{Type: types.ExpectJSON, Target: "$.data.name", Value: types.String("john")},
+					{Type: types.ExpectHeaders, Target: "Content-Type", Value: types.String("application/json")},
+					{Type: types.ExpectMaxTime, Value: types.Duration(500 * time.Millisecond)},
+				},
+			},
+			ExtractorFn: func(section types.Section, content types.CleanedContent) (*types.Sentences, error) {
+				switch section {
+				case types.DoSection:
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "method",
+							Value: types.String("GET"),
+						},
+						{
+							Key:   "url",
+							Value: types.String("http://localhost:8080"),
+						},
+					}), nil
+				case types.ExpectSection:
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "status",
+							Value: types.Int(200),
+						},
+						{
+							Key:   "json",
+							Value: types.Map{"$.data.name": types.String("john"), "$.data.id": types.Int(12)},
+						},
+						{
+							Key:   "headers",
+							Value: types.Map{"Content-Type": types.String("application/json")},
+						},
+						{
+							Key:   "max_time",
+							Value: types.String("500ms"),
+						},
+					}), nil
+				}
+
+				return nil, nil
+			},
+		},
+		{
+			name:          "error expect invalid regex",
+			filename:      "expect.do",
+			expectedError: errors.New("invalid expectation matches: $.email: error parsing regexp: missing closing ): `(\\w+`"),
+			ExtractorFn: func(section types.Section, content types.CleanedContent) (*types.Sentences, error) {
+				switch section {
+				case types.DoSection:
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "method",
+							Value: types.String("GET"),
+						},
+						{
+							Key:   "url",
+							Value: types.String("http://localhost:8080"),
+						},
+					}), nil
+				case types.ExpectSection:
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "matches",
+							Value: types.Map{"$.email": types.String(`(\w+`)},
+						},
+					}), nil
+				}
+
+				return nil, nil
+			},
+		},
+		{
+			name:          "error expect unknown key",
+			filename:      "expect.do",
+			expectedError: errors.New("invalid expectation body: unknown expectation, use status, headers, json, contains, matches, max_time or max_size"),
+			ExtractorFn: func(section types.Section, content types.CleanedContent) (*types.Sentences, error) {
+				switch section {
+				case types.DoSection:
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "method",
+							Value: types.String("GET"),
+						},
+						{
+							Key:   "url",
+							Value: types.String("http://localhost:8080"),
+						},
+					}), nil
+				case types.ExpectSection:
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "body",
+							Value: types.String("ok"),
+						},
+					}), nil
+				}
+
+				return nil, nil
+			},
+		},
+		{
+			name:     "success snapshot",
+			filename: "snapshot.do",
+			expected: &types.DoFile{
+				Do: types.Do{
+					Method: types.String("GET"),
+					URL:    types.String("http://localhost:8080"),
+				},
+				Snapshot: &types.SnapshotConfig{
+					Headers: []string{"Content-Type", "ETag"},
+					Ignore:  []string{"$.createdAt", "$.items[*].id"},
+				},
+			},
+			ExtractorFn: func(section types.Section, content types.CleanedContent) (*types.Sentences, error) {
+				switch section {
+				case types.DoSection:
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "method",
+							Value: types.String("GET"),
+						},
+						{
+							Key:   "url",
+							Value: types.String("http://localhost:8080"),
+						},
+					}), nil
+				case types.SnapshotSection:
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "headers",
+							Value: types.String("Content-Type, ETag"),
+						},
+						{
+							Key:   "ignore",
+							Value: types.String("$.createdAt,$.items[*].id,"),
+						},
+					}), nil
+				}
+
+				return nil, nil
+			},
+		},
+		{
+			name:          "error snapshot invalid ignore path",
+			filename:      "snapshot.do",
+			expectedError: errors.New("invalid snapshot ignore: invalid json path createdAt: it must start with $"),
+			ExtractorFn: func(section types.Section, content types.CleanedContent) (*types.Sentences, error) {
+				switch section {
+				case types.DoSection:
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "method",
+							Value: types.String("GET"),
+						},
+						{
+							Key:   "url",
+							Value: types.String("http://localhost:8080"),
+						},
+					}), nil
+				case types.SnapshotSection:
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "ignore",
+							Value: types.String("createdAt"),
+						},
+					}), nil
+				}
+
+				return nil, nil
+			},
+		},
+		{
+			name:      "success variables replace let variables",
+			filename:  "variables.do",
+			variables: types.Map{"token": types.String("captured")},
+			expected: &types.DoFile{
+				Let: types.Let{
+					Variables: map[string]interface{}{"token": types.String("captured"), "base": types.String("http://localhost:8080")},
+					Secrets:   []string{"token"},
+				},
+				Do: types.Do{
+					Method: types.String("GET"),
+					URL:    types.String("http://localhost:8080"),
+				},
+			},
+			ExtractorFn: func(section types.Section, content types.CleanedContent) (*types.Sentences, error) {
+				if section == types.DoSection {
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "method",
+							Value: types.String("GET"),
+						},
+						{
+							Key:   "url",
+							Value: types.String("http://localhost:8080"),
+						},
+					}), nil
+				}
+
+				return nil, nil
+			},
+			ResolverFn: func(variables *types.Sentences) (*types.Sentences, error) {
+				return types.NewSentencesFromSlice([]types.Sentence{
+					{
+						Key:   "token",
+						Value: types.String("default"),
+					},
+					{
+						Key:   "base",
+						Value: types.String("http://localhost:8080"),
+					},
+				}), nil
+			},
+		},
+		{
+			name:          "error unknown option",
+			filename:      "options.do",
+			expectedError: errors.New("invalid option retry: unknown option"),
+			ExtractorFn: func(section types.Section, content types.CleanedContent) (*types.Sentences, error) {
+				switch section {
+				case types.DoSection:
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "method",
+							Value: types.String("GET"),
+						},
+						{
+							Key:   "url",
+							Value: types.String("http://localhost:8080"),
+						},
+					}), nil
+				case types.OptionsSection:
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "retry",
+							Value: types.Int(3),
+						},
+					}), nil
+				}
+
+				return nil, nil
+			},
+		},
+		{
+			name:          "error invalid timeout",
+			filename:      "options.do",
+			expectedError: errors.New(`invalid option timeout: expected a duration like "10s", got soon`),
+			ExtractorFn: func(section types.Section, content types.CleanedContent) (*types.Sentences, error) {
+				switch section {
+				case types.DoSection:
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "method",
+							Value: types.String("GET"),
+						},
+						{
+							Key:   "url",
+							Value: types.String("http://localhost:8080"),
+						},
+					}), nil
+				case types.OptionsSection:
+					return types.NewSentencesFromSlice([]types.Sentence{
+						{
+							Key:   "timeout",
+							Value: types.String("soon"),
+						},
+					}), nil
+				}
+
 				return nil, nil
 			},
 		},
@@ -112,7 +635,7 @@ func TestParser_FromFilename(t *testing.T) {
 			funcCaller.CallFn = tc.CallerFn
 			letResolver.ResolveFn = tc.ResolverFn
 
-			doFile, err := p.ParseFromFilename(tc.filename)
+			doFile, err := p.WithVariables(tc.variables).ParseFromFilename(tc.filename)
 
 			if err != nil && tc.expectedError == nil {
 				t.Errorf("expected no error, got %v", err)
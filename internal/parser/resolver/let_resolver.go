@@ -13,15 +13,18 @@ type LetResolver interface {
 type letResolver struct {
 	uuidFactory utils.UuidFactory
 	dateFactory utils.DateFactory
+	storeReader types.StoreReader
 }
 
 func NewLetResolver(
 	uuidFactory utils.UuidFactory,
 	dateFactory utils.DateFactory,
+	storeReader types.StoreReader,
 ) LetResolver {
 	return &letResolver{
 		uuidFactory,
 		dateFactory,
+		storeReader,
 	}
 }
 
@@ -84,6 +87,7 @@ func (r *letResolver) ResolveFunction(fn types.Func, key string, resolvedVariabl
 	resolvedFn, err := fn.Resolve(
 		r.uuidFactory,
 		r.dateFactory,
+		r.storeReader,
 	)
 	if err != nil {
 		return err
@@ -102,6 +106,12 @@ func (r *letResolver) ResolveFunction(fn types.Func, key string, resolvedVariabl
 	case types.DateFunc:
 		dateFunc := resolvedFn.(types.DateFunc)
 		resolvedVariables.Set(key, dateFunc.Exec())
+	case types.StoreFunc:
+		value, err := resolvedFn.(types.StoreFunc).Exec()
+		if err != nil {
+			return err
+		}
+		resolvedVariables.Set(key, value)
 	}
 
 	return nil
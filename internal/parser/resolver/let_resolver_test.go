@@ -1,11 +1,13 @@
 package resolver_test
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/jibaru/do/internal/parser/resolver"
+	"github.com/jibaru/do/internal/store"
 	"github.com/jibaru/do/internal/types"
 	"github.com/jibaru/do/internal/utils"
 )
@@ -123,6 +125,70 @@ func TestLetResolver_Resolve(t *testing.T) {
 				},
 			}),
 		},
+		{
+			name: "success with store",
+			variables: types.NewSentencesFromSlice([]types.Sentence{
+				{
+					Key: "token",
+					Value: types.Func{
+						Name: "store",
+						Args: []interface{}{types.String("token")},
+					},
+				},
+				{
+					Key: "userId",
+					Value: types.Func{
+						Name: "store",
+						Args: []interface{}{types.String("userId")},
+					},
+				},
+				{
+					Key: "missing",
+					Value: types.Func{
+						Name: "store",
+						Args: []interface{}{types.String("missing"), types.String("default")},
+					},
+				},
+			}),
+			expected: types.NewSentencesFromSlice([]types.Sentence{
+				{
+					Key:   "token",
+					Value: types.String("abc"),
+				},
+				{
+					Key:   "userId",
+					Value: types.Int(12),
+				},
+				{
+					Key:   "missing",
+					Value: types.String("default"),
+				},
+			}),
+		},
+		{
+			name: "error invalid store",
+			variables: types.NewSentencesFromSlice([]types.Sentence{
+				{
+					Key: "token",
+					Value: types.Func{
+						Name: "store",
+						Args: []interface{}{types.String("broken")},
+					},
+				},
+			}),
+			expectedError: errors.New("invalid store"),
+		},
+	}
+
+	storeReader := &store.Mock{
+		GetFn: func(name string) (interface{}, bool, error) {
+			values := map[string]interface{}{"token": types.String("abc"), "userId": types.Int(12)}
+			if name == "broken" {
+				return nil, false, errors.New("invalid store")
+			}
+			value, ok := values[name]
+			return value, ok, nil
+		},
 	}
 
 	for _, tc := range testCases {
@@ -130,6 +196,7 @@ func TestLetResolver_Resolve(t *testing.T) {
 			r := resolver.NewLetResolver(
 				utils.NewFixedUuidFactory(uuid),
 				utils.NewFixedDateFactory(now),
+				storeReader,
 			)
 			resolvedVariables, err := r.Resolve(tc.variables)
 
@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/jibaru/do/internal/jsonpath"
+	"github.com/jibaru/do/internal/types"
+)
+
+// toSnapshotConfig returns the snapshot config defined by the sentences of the snapshot section.
+// Each value is a comma separated list, like "Content-Type, ETag" or "$.id, $.items[*].createdAt".
+func toSnapshotConfig(sentences *types.Sentences) (*types.SnapshotConfig, error) {
+	config := &types.SnapshotConfig{Headers: make([]string, 0), Ignore: make([]string, 0)}
+
+	for _, sentence := range sentences.Entries() {
+		value, ok := sentence.Value.(types.String)
+		if !ok {
+			return nil, NewInvalidSnapshotError(sentence.Key, "expected a comma separated list")
+		}
+
+		items := make([]string, 0)
+		for _, item := range strings.Split(string(value), ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+
+		switch sentence.Key {
+		case types.SnapshotHeaders:
+			config.Headers = items
+		case types.SnapshotIgnore:
+			for _, item := range items {
+				if _, err := jsonpath.Parse(item); err != nil {
+					return nil, NewInvalidSnapshotError(sentence.Key, err.Error())
+				}
+			}
+			config.Ignore = items
+		default:
+			return nil, NewInvalidSnapshotError(sentence.Key, "unknown field, use headers or ignore")
+		}
+	}
+
+	return config, nil
+}
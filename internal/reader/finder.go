@@ -0,0 +1,50 @@
+package reader
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// DoFileExtension defines the extension of the .do files
+const DoFileExtension = ".do"
+
+// FindDoFiles returns the .do files in the given paths. Directories are walked
+// recursively and files are returned as they are, regardless of their extension.
+func FindDoFiles(paths ...string) ([]string, error) {
+	filenames := make([]string, 0)
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, NewCanNotReadFileError(path)
+		}
+
+		if !info.IsDir() {
+			filenames = append(filenames, path)
+			continue
+		}
+
+		found := make([]string, 0)
+		err = filepath.WalkDir(path, func(filename string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return NewCanNotReadFileError(filename)
+			}
+
+			if !entry.IsDir() && filepath.Ext(filename) == DoFileExtension {
+				found = append(found, filename)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		sort.Strings(found)
+		filenames = append(filenames, found...)
+	}
+
+	return filenames, nil
+}
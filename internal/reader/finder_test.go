@@ -0,0 +1,63 @@
+package reader_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jibaru/do/internal/reader"
+)
+
+func TestFindDoFiles(t *testing.T) {
+	testCases := []struct {
+		name          string
+		paths         []string
+		expected      []string
+		expectedError error
+	}{
+		{
+			name:     "success single file",
+			paths:    []string{"testdata/01.do"},
+			expected: []string{"testdata/01.do"},
+		},
+		{
+			name:  "success directory",
+			paths: []string{"testdata/nested"},
+			expected: []string{
+				"testdata/nested/02.do",
+				"testdata/nested/deeper/03.do",
+			},
+		},
+		{
+			name:  "success file and directory",
+			paths: []string{"testdata/nested/notes.txt", "testdata/nested/deeper"},
+			expected: []string{
+				"testdata/nested/notes.txt",
+				"testdata/nested/deeper/03.do",
+			},
+		},
+		{
+			name:          "error path not found",
+			paths:         []string{"testdata/not_found"},
+			expectedError: errors.New("can not read file testdata/not_found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filenames, err := reader.FindDoFiles(tc.paths...)
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if tc.expectedError == nil && !reflect.DeepEqual(filenames, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, filenames)
+			}
+		})
+	}
+}
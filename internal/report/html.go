@@ -0,0 +1,248 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+type html struct {
+	now func() time.Time
+}
+
+// NewHTML returns a reporter of a single HTML page, without external assets, with a
+// collapsible section by do file showing its request, response, timings, assertions and snapshot
+func NewHTML() Reporter {
+	return &html{now: time.Now}
+}
+
+// htmlPage defines the data rendered by the template
+type htmlPage struct {
+	GeneratedAt string
+	Passed      int
+	Failed      int
+	Duration    float64
+	Results     []types.TestResult
+}
+
+func (h *html) Report(results []types.TestResult) ([]byte, error) {
+	page := htmlPage{
+		GeneratedAt: h.now().Format(time.RFC1123),
+		Results:     results,
+	}
+
+	for _, result := range results {
+		if result.Passed() {
+			page.Passed++
+		} else {
+			page.Failed++
+		}
+		page.Duration += result.Duration
+	}
+
+	content := bytes.Buffer{}
+	if err := htmlTemplate.Execute(&content, page); err != nil {
+		return nil, err
+	}
+
+	return content.Bytes(), nil
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"pretty":       pretty,
+	"text":         text,
+	"headerValues": headerValues,
+	"milliseconds": milliseconds,
+}).Parse(htmlSource))
+
+// pretty returns the body indented when it is json, or as it is otherwise
+func pretty(body string) string {
+	indented := bytes.Buffer{}
+	if err := json.Indent(&indented, []byte(body), "", "  "); err != nil {
+		return body
+	}
+
+	return indented.String()
+}
+
+// text returns the json representation of an expected or actual value
+func text(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	content, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(content)
+}
+
+// headerValues returns the values of a header separated by commas
+func headerValues(value interface{}) string {
+	if values, ok := value.([]string); ok {
+		return strings.Join(values, ", ")
+	}
+
+	return fmt.Sprintf("%v", value)
+}
+
+// milliseconds returns the milliseconds rounded, or "-" when the phase did not happen
+func milliseconds(value float64) string {
+	if value < 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.1f ms", value)
+}
+
+const htmlSource = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>do report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem; color: #1f2328; background: #fff; }
+h1 { font-size: 1.5rem; margin: 0 0 .25rem; }
+.meta { color: #59636e; margin-bottom: 1.5rem; }
+.summary span { display: inline-block; margin-right: 1rem; font-weight: 600; }
+details { border: 1px solid #d1d9e0; border-radius: 6px; margin: .5rem 0; }
+details details { border: none; border-top: 1px solid #d1d9e0; border-radius: 0; margin: 0; }
+summary { cursor: pointer; padding: .6rem .8rem; }
+details > div { padding: 0 .8rem .8rem; }
+.badge { display: inline-block; min-width: 3rem; padding: .1rem .4rem; border-radius: 4px; color: #fff; font-size: .8rem; font-weight: 700; text-align: center; }
+.pass { background: #1a7f37; }
+.fail { background: #cf222e; }
+.passed { color: #1a7f37; }
+.failed { color: #cf222e; }
+.method { font-weight: 700; margin: 0 .5rem; }
+.muted { color: #59636e; }
+.error { color: #cf222e; font-weight: 600; white-space: pre-wrap; }
+table { border-collapse: collapse; width: 100%; margin: .5rem 0; font-size: .9rem; }
+th, td { border: 1px solid #d1d9e0; padding: .3rem .5rem; text-align: left; vertical-align: top; word-break: break-all; }
+th { background: #f6f8fa; }
+pre { background: #f6f8fa; padding: .6rem; border-radius: 6px; overflow-x: auto; white-space: pre-wrap; word-break: break-all; }
+</style>
+</head>
+<body>
+<h1>do report</h1>
+<div class="meta">Generated at {{.GeneratedAt}}</div>
+<div class="summary">
+<span class="passed">{{.Passed}} passed</span>
+<span class="failed">{{.Failed}} failed</span>
+<span>{{len .Results}} total</span>
+<span class="muted">{{milliseconds .Duration}}</span>
+</div>
+{{range .Results}}
+<details{{if not .Passed}} open{{end}}>
+<summary>
+{{if .Passed}}<span class="badge pass">PASS</span>{{else}}<span class="badge fail">FAIL</span>{{end}}
+<span class="method">{{if .Output.Request}}{{.Output.Request.Method}}{{else}}{{.Output.DoFile.Do.Method}}{{end}}</span>
+{{if .Output.Request}}{{.Output.Request.URL}}{{else}}{{.Output.DoFile.Do.URL}}{{end}}
+{{if .Output.Response}}<span class="muted">&rarr; {{.Output.Response.StatusCode}}</span>{{end}}
+<span class="muted">{{.Filename}} &middot; {{milliseconds .Duration}}</span>
+</summary>
+<div>
+{{with .Output.Error}}<p class="error">{{.}}</p>{{end}}
+{{with .Output.Assertions}}
+<details open>
+<summary>Assertions</summary>
+<div>
+<table>
+<tr><th></th><th>Type</th><th>Target</th><th>Expected</th><th>Actual</th><th>Message</th></tr>
+{{range .}}
+<tr>
+<td>{{if .Passed}}<span class="passed">&#10003;</span>{{else}}<span class="failed">&#10007;</span>{{end}}</td>
+<td>{{.Type}}</td>
+<td>{{.Target}}</td>
+<td>{{text .Expected}}</td>
+<td>{{text .Actual}}</td>
+<td>{{.Message}}</td>
+</tr>
+{{end}}
+</table>
+</div>
+</details>
+{{end}}
+{{with .Output.Request}}
+<details>
+<summary>Request</summary>
+<div>
+<p><strong>{{.Method}}</strong> {{.URL}}</p>
+{{range .Redirects}}<p class="muted">{{.StatusCode}} {{.URL}} &rarr; {{.Location}}</p>{{end}}
+{{with .Headers}}
+<table>
+<tr><th>Header</th><th>Value</th></tr>
+{{range $key, $value := .}}<tr><td>{{$key}}</td><td>{{headerValues $value}}</td></tr>
+{{end}}
+</table>
+{{end}}
+{{with .Body}}<pre>{{pretty .}}</pre>{{end}}
+</div>
+</details>
+{{end}}
+{{with .Output.Response}}
+<details>
+<summary>Response <span class="muted">{{.StatusCode}} {{.Proto}}</span></summary>
+<div>
+{{with .Headers}}
+<table>
+<tr><th>Header</th><th>Value</th></tr>
+{{range $key, $value := .}}<tr><td>{{$key}}</td><td>{{headerValues $value}}</td></tr>
+{{end}}
+</table>
+{{end}}
+{{with .Body}}<pre>{{pretty .}}</pre>{{end}}
+</div>
+</details>
+<details>
+<summary>Timings</summary>
+<div>
+<table>
+<tr><th>Blocked</th><th>DNS</th><th>Connect</th><th>SSL</th><th>Send</th><th>Wait</th><th>Receive</th></tr>
+<tr>
+<td>{{milliseconds .Timings.Blocked}}</td>
+<td>{{milliseconds .Timings.DNS}}</td>
+<td>{{milliseconds .Timings.Connect}}</td>
+<td>{{milliseconds .Timings.SSL}}</td>
+<td>{{milliseconds .Timings.Send}}</td>
+<td>{{milliseconds .Timings.Wait}}</td>
+<td>{{milliseconds .Timings.Receive}}</td>
+</tr>
+</table>
+</div>
+</details>
+{{end}}
+{{with .Output.Snapshot}}
+<details{{if not .Passed}} open{{end}}>
+<summary>Snapshot <span class="muted">{{.Path}}{{if .Updated}} (updated){{end}}</span></summary>
+<div>
+{{if .Diff}}<pre>{{.Diff}}</pre>{{else}}<p class="passed">The response matches the snapshot.</p>{{end}}
+</div>
+</details>
+{{end}}
+{{with .Output.Captures}}
+<details>
+<summary>Captures</summary>
+<div>
+<table>
+<tr><th>Name</th><th>Value</th></tr>
+{{range $key, $value := .}}<tr><td>{{$key}}</td><td>{{text $value}}</td></tr>
+{{end}}
+</table>
+</div>
+</details>
+{{end}}
+</div>
+</details>
+{{end}}
+</body>
+</html>
+`
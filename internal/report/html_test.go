@@ -0,0 +1,82 @@
+package report_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jibaru/do/internal/report"
+	"github.com/jibaru/do/internal/types"
+)
+
+func TestHTMLReporter_Report(t *testing.T) {
+	withResponse := results()
+	withResponse[0].Output.Request = &types.Request{
+		Method:  "POST",
+		URL:     "https://api.example.com/users?page=1",
+		Headers: map[string]interface{}{"Authorization": []string{"****"}},
+		Body:    `{"name":"<john>"}`,
+	}
+	withResponse[0].Output.Response = &types.Response{
+		StatusCode: 201,
+		Proto:      "HTTP/1.1",
+		Body:       `{"id":12}`,
+		Timings:    types.Timings{DNS: -1, Wait: 80.25},
+	}
+	withResponse[0].Output.Captures = types.Map{"userId": types.Int(12)}
+
+	testCases := []struct {
+		name          string
+		results       []types.TestResult
+		expected      []string
+		expectedError error
+	}{
+		{
+			name:    "success",
+			results: withResponse,
+			expected: []string{
+				`<span class="passed">1 passed</span>`,
+				`<span class="failed">2 failed</span>`,
+				`<span>3 total</span>`,
+				`<span class="method">POST</span>`,
+				`https://api.example.com/users?page=1`,
+				`<tr><td>Authorization</td><td>****</td></tr>`,
+				"<pre>{\n  &#34;name&#34;: &#34;&lt;john&gt;&#34;\n}</pre>",
+				"<pre>{\n  &#34;id&#34;: 12\n}</pre>",
+				`<td>-</td>`,
+				`<td>80.2 ms</td>`,
+				`<tr><td>userId</td><td>12</td></tr>`,
+				`<td>expected status 201, got 200</td>`,
+				`<p class="error">can not do request: connection refused</p>`,
+				`<details open>`,
+			},
+		},
+	}
+
+	r := report.NewHTML()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content, err := r.Report(tc.results)
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			for _, expected := range tc.expected {
+				if !strings.Contains(string(content), expected) {
+					t.Errorf("expected %q in %s", expected, content)
+				}
+			}
+
+			for _, external := range []string{"<script", "<link", "src=", "href="} {
+				if strings.Contains(string(content), external) {
+					t.Errorf("expected no external assets, got %q", external)
+				}
+			}
+		})
+	}
+}
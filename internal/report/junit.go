@@ -0,0 +1,114 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+type junit struct{}
+
+// NewJUnit returns a reporter of JUnit XML, with a test suite by directory and a test case by do file
+func NewJUnit() Reporter {
+	return &junit{}
+}
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Errors   int          `xml:"errors,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Errors    int         `xml:"errors,attr"`
+	Time      string      `xml:"time,attr"`
+	Timestamp string      `xml:"timestamp,attr"`
+	Cases     []junitCase `xml:"testcase"`
+	duration  float64
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure"`
+	Error     *junitProblem `xml:"error"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Content string `xml:",chardata"`
+}
+
+func (j *junit) Report(results []types.TestResult) ([]byte, error) {
+	report := junitSuites{Name: "do", Suites: make([]junitSuite, 0)}
+	suites := make(map[string]int)
+	duration := 0.0
+
+	for _, result := range results {
+		dir := filepath.ToSlash(filepath.Dir(result.Filename))
+		idx, ok := suites[dir]
+		if !ok {
+			idx = len(report.Suites)
+			suites[dir] = idx
+			report.Suites = append(report.Suites, junitSuite{
+				Name:      dir,
+				Timestamp: result.StartedAt.UTC().Format(time.RFC3339),
+				Cases:     make([]junitCase, 0),
+			})
+		}
+		suite := &report.Suites[idx]
+
+		testCase := junitCase{
+			Name:      filepath.Base(result.Filename),
+			Classname: dir,
+			Time:      seconds(result.Duration),
+		}
+
+		failures := result.Failures()
+		if result.Output.Error != nil {
+			testCase.Error = &junitProblem{Message: *result.Output.Error, Type: "error", Content: strings.Join(failures, "\n")}
+			suite.Errors++
+			report.Errors++
+		} else if len(failures) > 0 {
+			testCase.Failure = &junitProblem{Message: failures[0], Type: "assertion", Content: strings.Join(failures, "\n")}
+			suite.Failures++
+			report.Failures++
+		}
+
+		suite.Cases = append(suite.Cases, testCase)
+		suite.Tests++
+		suite.duration += result.Duration
+		report.Tests++
+		duration += result.Duration
+	}
+
+	for i := range report.Suites {
+		report.Suites[i].Time = seconds(report.Suites[i].duration)
+	}
+	report.Time = seconds(duration)
+
+	content, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), append(content, '\n')...), nil
+}
+
+// seconds returns the milliseconds as seconds, the unit of the JUnit times
+func seconds(milliseconds float64) string {
+	return fmt.Sprintf("%.3f", milliseconds/1000)
+}
@@ -0,0 +1,64 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/jibaru/do/internal/report"
+	"github.com/jibaru/do/internal/types"
+)
+
+func TestJUnitReporter_Report(t *testing.T) {
+	testCases := []struct {
+		name          string
+		results       []types.TestResult
+		expected      string
+		expectedError error
+	}{
+		{
+			name:    "success",
+			results: results(),
+			expected: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="do" tests="3" failures="1" errors="1" time="0.203">
+  <testsuite name="api/users" tests="2" failures="1" errors="0" time="0.200" timestamp="2024-05-01T10:00:00Z">
+    <testcase name="get.do" classname="api/users" time="0.120"></testcase>
+    <testcase name="create.do" classname="api/users" time="0.080">
+      <failure message="expected status 201, got 200" type="assertion">expected status 201, got 200&#xA;expected $.name to be &#34;john&#34;, got &#34;jane&#34;</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="api" tests="1" failures="0" errors="1" time="0.003" timestamp="2024-05-01T10:00:00Z">
+    <testcase name="health.do" classname="api" time="0.003">
+      <error message="can not do request: connection refused" type="error">can not do request: connection refused</error>
+    </testcase>
+  </testsuite>
+</testsuites>
+`,
+		},
+		{
+			name:    "success without results",
+			results: []types.TestResult{},
+			expected: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="do" tests="0" failures="0" errors="0" time="0.000"></testsuites>
+`,
+		},
+	}
+
+	r := report.NewJUnit()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content, err := r.Report(tc.results)
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if string(content) != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, string(content))
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package report
+
+import "github.com/jibaru/do/internal/types"
+
+type Mock struct {
+	ReportFn func(results []types.TestResult) ([]byte, error)
+}
+
+func (m *Mock) Report(results []types.TestResult) ([]byte, error) {
+	return m.ReportFn(results)
+}
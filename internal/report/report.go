@@ -0,0 +1,8 @@
+package report
+
+import "github.com/jibaru/do/internal/types"
+
+type Reporter interface {
+	// Report renders the results of the do files sent by the test command.
+	Report(results []types.TestResult) ([]byte, error)
+}
@@ -0,0 +1,43 @@
+package report_test
+
+import (
+	"time"
+
+	"github.com/jibaru/do/internal/types"
+	"github.com/jibaru/do/internal/utils"
+)
+
+var startedAt = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+// results returns a passed, a failed and an errored result in two directories
+func results() []types.TestResult {
+	return []types.TestResult{
+		{
+			Filename:  "api/users/get.do",
+			StartedAt: startedAt,
+			Duration:  120.25,
+			Output: types.CommandLineOutput{
+				Assertions: []types.Assertion{{Type: types.ExpectStatus, Expected: types.Int(200), Actual: types.Int(200), Passed: true}},
+			},
+		},
+		{
+			Filename:  "api/users/create.do",
+			StartedAt: startedAt,
+			Duration:  80,
+			Output: types.CommandLineOutput{
+				Assertions: []types.Assertion{
+					{Type: types.ExpectStatus, Expected: types.Int(201), Actual: types.Int(200), Message: "expected status 201, got 200"},
+					{Type: types.ExpectJSON, Target: "$.name", Expected: types.String("john"), Actual: "jane", Message: `expected $.name to be "john", got "jane"`},
+				},
+			},
+		},
+		{
+			Filename:  "api/health.do",
+			StartedAt: startedAt,
+			Duration:  3,
+			Output: types.CommandLineOutput{
+				Error: utils.Ptr("can not do request: connection refused"),
+			},
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+type tap struct{}
+
+// NewTAP returns a reporter of TAP version 13, with a test point by do file and
+// the failures in its YAML block
+func NewTAP() Reporter {
+	return &tap{}
+}
+
+func (t *tap) Report(results []types.TestResult) ([]byte, error) {
+	content := strings.Builder{}
+	content.WriteString("TAP version 13\n")
+	content.WriteString(fmt.Sprintf("1..%d\n", len(results)))
+
+	for i, result := range results {
+		status := "ok"
+		if !result.Passed() {
+			status = "not ok"
+		}
+		content.WriteString(fmt.Sprintf("%s %d - %s\n", status, i+1, result.Filename))
+
+		content.WriteString("  ---\n")
+		content.WriteString(fmt.Sprintf("  duration_ms: %.3f\n", result.Duration))
+		if failures := result.Failures(); len(failures) > 0 {
+			content.WriteString("  failures:\n")
+			for _, failure := range failures {
+				// a json string is a valid YAML double quoted string
+				quoted, err := json.Marshal(failure)
+				if err != nil {
+					return nil, err
+				}
+				content.WriteString("    - " + string(quoted) + "\n")
+			}
+		}
+		content.WriteString("  ...\n")
+	}
+
+	return []byte(content.String()), nil
+}
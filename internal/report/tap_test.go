@@ -0,0 +1,67 @@
+package report_test
+
+import (
+	"testing"
+
+	"github.com/jibaru/do/internal/report"
+	"github.com/jibaru/do/internal/types"
+)
+
+func TestTAPReporter_Report(t *testing.T) {
+	testCases := []struct {
+		name          string
+		results       []types.TestResult
+		expected      string
+		expectedError error
+	}{
+		{
+			name:    "success",
+			results: results(),
+			expected: `TAP version 13
+1..3
+ok 1 - api/users/get.do
+  ---
+  duration_ms: 120.250
+  ...
+not ok 2 - api/users/create.do
+  ---
+  duration_ms: 80.000
+  failures:
+    - "expected status 201, got 200"
+    - "expected $.name to be \"john\", got \"jane\""
+  ...
+not ok 3 - api/health.do
+  ---
+  duration_ms: 3.000
+  failures:
+    - "can not do request: connection refused"
+  ...
+`,
+		},
+		{
+			name:     "success without results",
+			results:  []types.TestResult{},
+			expected: "TAP version 13\n1..0\n",
+		},
+	}
+
+	r := report.NewTAP()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			content, err := r.Report(tc.results)
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if string(content) != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, string(content))
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package request
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/url"
+	"strings"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+// digestChallenge defines the parameters of a WWW-Authenticate: Digest header
+type digestChallenge map[string]string
+
+// parseDigestChallenge returns the first digest challenge of the response headers
+func parseDigestChallenge(headers map[string]interface{}) (digestChallenge, bool) {
+	values, _ := headers["Www-Authenticate"].([]string)
+	for _, value := range values {
+		scheme, params, _ := strings.Cut(strings.TrimSpace(value), " ")
+		if !strings.EqualFold(scheme, "Digest") {
+			continue
+		}
+
+		challenge := digestChallenge{}
+		for _, param := range splitParams(params) {
+			key, val, _ := strings.Cut(param, "=")
+			challenge[strings.ToLower(strings.TrimSpace(key))] = strings.Trim(strings.TrimSpace(val), `"`)
+		}
+
+		return challenge, true
+	}
+
+	return nil, false
+}
+
+// splitParams splits the parameters of a challenge by commas outside quotes
+func splitParams(params string) []string {
+	result := make([]string, 0)
+	current := strings.Builder{}
+	inQuotes := false
+
+	for _, ch := range params {
+		switch {
+		case ch == '"':
+			inQuotes = !inQuotes
+		case ch == ',' && !inQuotes:
+			result = append(result, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(ch)
+	}
+
+	return append(result, current.String())
+}
+
+// digestAuthorization returns the Authorization header that answers the challenge, as defined in RFC 7616
+func digestAuthorization(auth types.Auth, challenge digestChallenge, method, rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	uri := u.RequestURI()
+
+	algorithm := challenge["algorithm"]
+	if algorithm == "" {
+		algorithm = "MD5"
+	}
+
+	session := strings.HasSuffix(strings.ToUpper(algorithm), "-SESS")
+
+	var newHash func() hash.Hash
+	switch strings.TrimSuffix(strings.ToUpper(algorithm), "-SESS") {
+	case "MD5":
+		newHash = md5.New
+	case "SHA-256":
+		newHash = sha256.New
+	default:
+		return "", NewUnsupportedDigestAlgorithmError(algorithm)
+	}
+
+	digest := func(parts ...string) string {
+		h := newHash()
+		h.Write([]byte(strings.Join(parts, ":")))
+		return hex.EncodeToString(h.Sum(nil))
+	}
+
+	nonce, realm := challenge["nonce"], challenge["realm"]
+	cnonce := make([]byte, 8)
+	if _, err = rand.Read(cnonce); err != nil {
+		return "", err
+	}
+	clientNonce := hex.EncodeToString(cnonce)
+	count := "00000001"
+
+	ha1 := digest(auth.Username, realm, auth.Password)
+	if session {
+		ha1 = digest(ha1, nonce, clientNonce)
+	}
+	ha2 := digest(method, uri)
+
+	qop := ""
+	for _, option := range strings.Split(challenge["qop"], ",") {
+		if strings.TrimSpace(option) == "auth" {
+			qop = "auth"
+		}
+	}
+
+	params := []string{
+		fmt.Sprintf("username=%q", auth.Username),
+		fmt.Sprintf("realm=%q", realm),
+		fmt.Sprintf("nonce=%q", nonce),
+		fmt.Sprintf("uri=%q", uri),
+		"algorithm=" + algorithm,
+	}
+
+	if qop != "" {
+		params = append(params,
+			fmt.Sprintf("response=%q", digest(ha1, nonce, count, clientNonce, qop, ha2)),
+			"qop="+qop,
+			"nc="+count,
+			fmt.Sprintf("cnonce=%q", clientNonce),
+		)
+	} else {
+		params = append(params, fmt.Sprintf("response=%q", digest(ha1, nonce, ha2)))
+	}
+
+	if opaque, ok := challenge["opaque"]; ok {
+		params = append(params, fmt.Sprintf("opaque=%q", opaque))
+	}
+
+	return "Digest " + strings.Join(params, ", "), nil
+}
+
+// maskAuthorization hides the credentials of the Authorization header, keeping its scheme,
+// and the session token of sigv4
+func maskAuthorization(headers map[string]interface{}) {
+	if _, ok := headers["X-Amz-Security-Token"]; ok {
+		headers["X-Amz-Security-Token"] = []string{types.Mask("token")}
+	}
+
+	values, ok := headers["Authorization"].([]string)
+	if !ok {
+		return
+	}
+
+	masked := make([]string, 0, len(values))
+	for _, value := range values {
+		scheme, _, _ := strings.Cut(value, " ")
+		masked = append(masked, scheme+" "+types.Mask(value))
+	}
+	headers["Authorization"] = masked
+}
@@ -0,0 +1,167 @@
+package request_test
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jibaru/do/internal/oauth2"
+	"github.com/jibaru/do/internal/request"
+	"github.com/jibaru/do/internal/types"
+)
+
+func TestHttpClient_Do_Auth(t *testing.T) {
+	md5Hex := func(parts ...string) string {
+		sum := md5.Sum([]byte(strings.Join(parts, ":")))
+		return hex.EncodeToString(sum[:])
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/basic", func(w http.ResponseWriter, r *http.Request) {
+		username, password, _ := r.BasicAuth()
+		_, _ = w.Write([]byte(username + ":" + password))
+	})
+	mux.HandleFunc("/bearer", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	})
+	mux.HandleFunc("/digest", func(w http.ResponseWriter, r *http.Request) {
+		params := map[string]string{}
+		scheme, values, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+		for _, param := range strings.Split(values, ", ") {
+			key, value, _ := strings.Cut(param, "=")
+			params[key] = strings.Trim(value, `"`)
+		}
+
+		ha1 := md5Hex("john", "do", "secret")
+		ha2 := md5Hex(r.Method, r.URL.RequestURI())
+		expected := md5Hex(ha1, "abc123", params["nc"], params["cnonce"], "auth", ha2)
+		if scheme != "Digest" || params["uri"] != r.URL.RequestURI() || params["response"] != expected ||
+			params["opaque"] != "xyz" {
+			w.Header().Set("WWW-Authenticate", `Digest realm="do", qop="auth,auth-int", nonce="abc123", opaque="xyz"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("welcome " + params["username"]))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	testCases := []struct {
+		name                  string
+		path                  string
+		auth                  types.Auth
+		expectedStatus        int
+		expectedBody          string
+		expectedAuthorization []string
+	}{
+		{
+			name:                  "success basic",
+			path:                  "/basic",
+			auth:                  types.Auth{Type: types.AuthBasic, Username: "john", Password: "secret"},
+			expectedStatus:        http.StatusOK,
+			expectedBody:          "john:secret",
+			expectedAuthorization: []string{"Basic ****"},
+		},
+		{
+			name:                  "success bearer",
+			path:                  "/bearer",
+			auth:                  types.Auth{Type: types.AuthBearer, Token: "token"},
+			expectedStatus:        http.StatusOK,
+			expectedBody:          "Bearer token",
+			expectedAuthorization: []string{"Bearer ****"},
+		},
+		{
+			name:                  "success oauth2",
+			path:                  "/bearer",
+			auth:                  types.Auth{Type: types.AuthOAuth2, TokenURL: "https://auth.example.com/token", ClientID: "app"},
+			expectedStatus:        http.StatusOK,
+			expectedBody:          "Bearer app-token",
+			expectedAuthorization: []string{"Bearer ****"},
+		},
+		{
+			name:                  "success digest",
+			path:                  "/digest?page=1",
+			auth:                  types.Auth{Type: types.AuthDigest, Username: "john", Password: "secret"},
+			expectedStatus:        http.StatusOK,
+			expectedBody:          "welcome john",
+			expectedAuthorization: []string{"Digest ****"},
+		},
+		{
+			name:                  "success digest wrong password",
+			path:                  "/digest",
+			auth:                  types.Auth{Type: types.AuthDigest, Username: "john", Password: "wrong"},
+			expectedStatus:        http.StatusUnauthorized,
+			expectedBody:          "",
+			expectedAuthorization: []string{"Digest ****"},
+		},
+	}
+
+	tokens := &oauth2.Mock{
+		TokenFn: func(auth types.Auth) (string, error) {
+			return auth.ClientID + "-token", nil
+		},
+	}
+	tokens.WithClientFn = func(client *http.Client) oauth2.TokenSource {
+		return tokens
+	}
+	client := request.NewHttpClient(&http.Client{}, tokens)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			auth := tc.auth
+			sent, response, err := client.Do(types.DoFile{
+				Do: types.Do{Method: "GET", URL: types.String(server.URL + tc.path), Auth: &auth},
+			})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if response.StatusCode != tc.expectedStatus || response.Body != tc.expectedBody {
+				t.Errorf("expected %v %q, got %v %q", tc.expectedStatus, tc.expectedBody, response.StatusCode, response.Body)
+			}
+
+			if !reflect.DeepEqual(sent.Headers["Authorization"], tc.expectedAuthorization) {
+				t.Errorf("expected authorization %v, got %v", tc.expectedAuthorization, sent.Headers["Authorization"])
+			}
+		})
+	}
+}
+
+func TestHttpClient_Do_OAuth2Options(t *testing.T) {
+	release := make(chan struct{})
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer tokenServer.Close()
+	defer close(release)
+
+	client := request.NewHttpClient(&http.Client{}, oauth2.New(&http.Client{}, ""))
+
+	startedAt := time.Now()
+	_, _, err := client.Do(types.DoFile{
+		Do: types.Do{
+			Method: "GET",
+			URL:    "http://localhost/me",
+			Auth: &types.Auth{
+				Type:         types.AuthOAuth2,
+				GrantType:    types.GrantClientCredentials,
+				TokenURL:     tokenServer.URL,
+				ClientID:     "app",
+				ClientSecret: "secret",
+			},
+		},
+		Options: types.Options{Timeout: types.Duration(100 * time.Millisecond)},
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "Timeout") {
+		t.Errorf("expected a timeout error, got %v", err)
+	}
+	if elapsed := time.Since(startedAt); elapsed > 2*time.Second {
+		t.Errorf("expected the token request to stop at the timeout, it took %v", elapsed)
+	}
+}
@@ -1,5 +1,7 @@
 package request
 
+import "strconv"
+
 type CanNotDoRequestError struct {
 	err error
 }
@@ -12,6 +14,30 @@ type CanNotReplaceParamError struct {
 	key string
 }
 
+type CanNotDumpRequestError struct {
+	err error
+}
+
+type TooManyRedirectsError struct {
+	limit int
+}
+
+type CanNotConfigureTLSError struct {
+	err error
+}
+
+type CanNotConfigureProxyError struct {
+	err error
+}
+
+type UnsupportedDigestAlgorithmError struct {
+	algorithm string
+}
+
+type CanNotSignRequestError struct {
+	err error
+}
+
 func NewCanNotDoRequestError(err error) error {
 	return CanNotDoRequestError{err}
 }
@@ -24,14 +50,70 @@ func NewCanNotReplaceParamError(key string) error {
 	return CanNotReplaceParamError{key}
 }
 
+func NewCanNotDumpRequestError(err error) error {
+	return CanNotDumpRequestError{err}
+}
+
+func NewTooManyRedirectsError(limit int) error {
+	return TooManyRedirectsError{limit}
+}
+
+func NewCanNotConfigureTLSError(err error) error {
+	return CanNotConfigureTLSError{err}
+}
+
+func NewCanNotConfigureProxyError(err error) error {
+	return CanNotConfigureProxyError{err}
+}
+
+func NewUnsupportedDigestAlgorithmError(algorithm string) error {
+	return UnsupportedDigestAlgorithmError{algorithm}
+}
+
+func NewCanNotSignRequestError(err error) error {
+	return CanNotSignRequestError{err}
+}
+
 func (e CanNotDoRequestError) Error() string {
 	return "can not do request: " + e.err.Error()
 }
 
+func (e CanNotDoRequestError) Unwrap() error {
+	return e.err
+}
+
 func (e CanNotReadResponseBodyError) Error() string {
 	return "can not read response body: " + e.err.Error()
 }
 
+func (e CanNotReadResponseBodyError) Unwrap() error {
+	return e.err
+}
+
 func (e CanNotReplaceParamError) Error() string {
 	return "can not replace param: " + e.key
 }
+
+func (e CanNotDumpRequestError) Error() string {
+	return "can not dump request: " + e.err.Error()
+}
+
+func (e TooManyRedirectsError) Error() string {
+	return "stopped after " + strconv.Itoa(e.limit) + " redirects"
+}
+
+func (e CanNotConfigureTLSError) Error() string {
+	return "can not configure tls: " + e.err.Error()
+}
+
+func (e CanNotConfigureProxyError) Error() string {
+	return "can not configure proxy: " + e.err.Error()
+}
+
+func (e UnsupportedDigestAlgorithmError) Error() string {
+	return "unsupported digest algorithm " + e.algorithm
+}
+
+func (e CanNotSignRequestError) Error() string {
+	return "can not sign request: " + e.err.Error()
+}
@@ -0,0 +1,172 @@
+package request
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+const (
+	// defaultBackoff is the delay before the first retry when the options do not define one
+	defaultBackoff = 100 * time.Millisecond
+	// defaultMaxBackoff is the longest delay between retries when the options do not define one
+	defaultMaxBackoff = 10 * time.Second
+	// defaultMaxRedirects is the number of redirects followed when the options do not define one
+	defaultMaxRedirects = 10
+)
+
+// withOptions returns a copy of the client that applies the timeouts, the redirect policy
+// and the tls configuration of the options
+func withOptions(client *http.Client, options types.Options) (*http.Client, error) {
+	configured := *client
+	if options.Timeout > 0 {
+		configured.Timeout = time.Duration(options.Timeout)
+	}
+
+	if options.FollowRedirects != nil || options.MaxRedirects > 0 || options.KeepAuthorization {
+		configured.CheckRedirect = redirectPolicy(options)
+	}
+
+	if !hasTransportOptions(options) {
+		return &configured, nil
+	}
+
+	var transport *http.Transport
+	switch next := client.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = next.Clone()
+	default:
+		// the phases, the tls and the proxy of a custom transport can not be configured
+		return &configured, nil
+	}
+
+	if options.ConnectTimeout > 0 {
+		dialer := &net.Dialer{Timeout: time.Duration(options.ConnectTimeout), KeepAlive: 30 * time.Second}
+		transport.DialContext = dialer.DialContext
+	}
+	if options.TLSTimeout > 0 {
+		transport.TLSHandshakeTimeout = time.Duration(options.TLSTimeout)
+	}
+	if options.ResponseTimeout > 0 {
+		transport.ResponseHeaderTimeout = time.Duration(options.ResponseTimeout)
+	}
+	if hasTLSOptions(options) {
+		config, err := tlsConfig(options)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = config
+	}
+
+	if hasProxyOptions(options) {
+		proxy, err := proxyFunc(options)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = proxy
+	}
+	configured.Transport = transport
+
+	return &configured, nil
+}
+
+// hasTransportOptions returns true when the options change the transport of the client
+func hasTransportOptions(options types.Options) bool {
+	return options.ConnectTimeout > 0 || options.TLSTimeout > 0 || options.ResponseTimeout > 0 ||
+		hasTLSOptions(options) || hasProxyOptions(options)
+}
+
+// redirectPolicy returns the function that decides if a redirect is followed. net/http removes
+// the Authorization header when the redirect goes to another domain, it is added back when
+// the options keep it.
+func redirectPolicy(options types.Options) func(req *http.Request, via []*http.Request) error {
+	limit := options.MaxRedirects
+	if limit == 0 {
+		limit = defaultMaxRedirects
+	}
+
+	return func(req *http.Request, via []*http.Request) error {
+		if options.FollowRedirects != nil && !*options.FollowRedirects {
+			return http.ErrUseLastResponse
+		}
+
+		if len(via) > limit {
+			return NewTooManyRedirectsError(limit)
+		}
+
+		if authorization := via[0].Header.Get("Authorization"); options.KeepAuthorization && authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+
+		return nil
+	}
+}
+
+// shouldRetry returns true when the response has a status code to retry on, or when
+// the request failed by a network error and the options allow to retry on them
+func shouldRetry(options types.Options, response *types.Response, err error) bool {
+	if err != nil {
+		return options.RetryOnNetworkError && isNetworkError(err)
+	}
+
+	for _, code := range options.RetryOn {
+		if response.StatusCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isNetworkError returns true when the request failed in the transport, like a refused connection, a reset,
+// a timeout or a connection closed before the response. Errors that happen again on a retry, like too many
+// redirects or an invalid certificate, are not.
+func isNetworkError(err error) bool {
+	var tooManyRedirects TooManyRedirectsError
+	if errors.As(err, &tooManyRedirects) {
+		return false
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		if errors.Is(urlErr.Err, io.EOF) || errors.Is(urlErr.Err, io.ErrUnexpectedEOF) {
+			return true
+		}
+		// *url.Error is a net.Error itself, the error of the transport decides
+		err = urlErr.Err
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// backoff returns the delay before the retry after the given attempt, starting at zero.
+// The delay doubles on each attempt up to the max backoff, and a random half of it is
+// removed to spread the retries of different clients.
+func backoff(options types.Options, attempt int) time.Duration {
+	delay, limit := time.Duration(options.Backoff), time.Duration(options.MaxBackoff)
+	if delay == 0 {
+		delay = defaultBackoff
+	}
+	if limit == 0 {
+		limit = defaultMaxBackoff
+	}
+
+	for i := 0; i < attempt && delay < limit; i++ {
+		delay *= 2
+	}
+	if delay > limit {
+		delay = limit
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
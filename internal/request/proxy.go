@@ -0,0 +1,126 @@
+package request
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+// proxySchemes defines the schemes supported by the transport for proxies
+var proxySchemes = map[string]bool{"http": true, "https": true, "socks5": true, "socks5h": true}
+
+// hasProxyOptions returns true when the options change the proxy taken from the environment
+func hasProxyOptions(options types.Options) bool {
+	return options.Proxy != "" || options.NoProxy != ""
+}
+
+// proxyFunc returns the function that selects the proxy of each request. The proxy and the hosts
+// excluded from it are taken from the options, or from the environment when they are not defined.
+func proxyFunc(options types.Options) (func(*http.Request) (*url.URL, error), error) {
+	var proxy *url.URL
+	if options.Proxy != "" {
+		var err error
+		if proxy, err = parseProxy(options.Proxy); err != nil {
+			return nil, err
+		}
+	}
+
+	noProxy := options.NoProxy
+	if noProxy == "" {
+		noProxy = environment("NO_PROXY")
+	}
+
+	return func(req *http.Request) (*url.URL, error) {
+		if bypassProxy(req.URL, noProxy) {
+			return nil, nil
+		}
+
+		if proxy != nil {
+			return proxy, nil
+		}
+
+		variable := environment("HTTP_PROXY")
+		if req.URL.Scheme == "https" {
+			variable = environment("HTTPS_PROXY")
+		}
+		if variable == "" {
+			return nil, nil
+		}
+
+		return parseProxy(variable)
+	}, nil
+}
+
+// parseProxy returns the url of the proxy, using http when it does not have a scheme
+func parseProxy(value string) (*url.URL, error) {
+	if !strings.Contains(value, "://") {
+		value = "http://" + value
+	}
+
+	proxy, err := url.Parse(value)
+	if err != nil {
+		return nil, NewCanNotConfigureProxyError(err)
+	}
+
+	if !proxySchemes[proxy.Scheme] {
+		return nil, NewCanNotConfigureProxyError(fmt.Errorf("unsupported proxy scheme %s", proxy.Scheme))
+	}
+
+	return proxy, nil
+}
+
+// bypassProxy returns true when the host of the url matches an entry of the comma separated
+// list: "*", a host that also matches its subdomains, a domain starting with a dot, an ip or a
+// cidr, optionally with a port
+func bypassProxy(u *url.URL, noProxy string) bool {
+	host, port := u.Hostname(), u.Port()
+	ip := net.ParseIP(host)
+
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+
+		if entry == "*" {
+			return true
+		}
+
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+
+		entryHost, entryPort, err := net.SplitHostPort(entry)
+		if err != nil {
+			entryHost, entryPort = entry, ""
+		}
+		if entryPort != "" && entryPort != port {
+			continue
+		}
+
+		entryHost = strings.TrimPrefix(entryHost, "*")
+		name := strings.ToLower(host)
+		if name == strings.TrimPrefix(entryHost, ".") || strings.HasSuffix(name, "."+strings.TrimPrefix(entryHost, ".")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// environment returns the value of the variable, looking for its lowercase name too
+func environment(name string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+
+	return os.Getenv(strings.ToLower(name))
+}
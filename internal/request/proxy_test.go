@@ -0,0 +1,177 @@
+package request_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jibaru/do/internal/request"
+	"github.com/jibaru/do/internal/types"
+)
+
+func TestHttpClient_Do_Proxy(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("direct"))
+	}))
+	defer target.Close()
+
+	httpProxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("proxied " + r.URL.String() + " " + r.Header.Get("Proxy-Authorization")))
+	}))
+	defer httpProxy.Close()
+
+	socksProxy := newSocksServer(t, "user", "secret")
+	defer socksProxy.Close()
+
+	testCases := []struct {
+		name          string
+		url           string
+		options       types.Options
+		expectedBody  string
+		expectedError error
+	}{
+		{
+			name:         "success http proxy with credentials",
+			url:          "http://api.example.com/users",
+			options:      types.Options{Proxy: "http://user:secret@" + httpProxy.Listener.Addr().String()},
+			expectedBody: "proxied http://api.example.com/users Basic dXNlcjpzZWNyZXQ=",
+		},
+		{
+			name:         "success socks5 proxy with credentials",
+			url:          "http://do.test:" + target.URL[strings.LastIndex(target.URL, ":")+1:],
+			options:      types.Options{Proxy: "socks5://user:secret@" + socksProxy.Addr().String()},
+			expectedBody: "direct",
+		},
+		{
+			name:         "success host excluded from proxy",
+			url:          target.URL,
+			options:      types.Options{Proxy: httpProxy.Listener.Addr().String(), NoProxy: "example.com, 127.0.0.0/8"},
+			expectedBody: "direct",
+		},
+		{
+			name:          "error unsupported proxy scheme",
+			url:           target.URL,
+			options:       types.Options{Proxy: "ftp://proxy:21"},
+			expectedError: request.NewCanNotConfigureProxyError(errors.New("unsupported proxy scheme ftp")),
+		},
+	}
+
+	client := request.NewHttpClient(&http.Client{}, nil)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, response, err := client.Do(types.DoFile{
+				Do:      types.Do{Method: "GET", URL: types.String(tc.url)},
+				Options: tc.options,
+			})
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if tc.expectedError == nil && response.Body != tc.expectedBody {
+				t.Errorf("expected body %q, got %q", tc.expectedBody, response.Body)
+			}
+		})
+	}
+}
+
+// newSocksServer starts a SOCKS5 server that only accepts the credentials and CONNECT commands.
+// The host do.test is resolved to 127.0.0.1 by the server, so it is only reachable through it.
+func newSocksServer(t *testing.T, username, password string) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSocks(conn, username, password)
+		}
+	}()
+
+	return listener
+}
+
+func serveSocks(conn net.Conn, username, password string) {
+	defer conn.Close()
+
+	// greeting: version, methods
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return
+	}
+	if _, err := io.ReadFull(conn, make([]byte, header[1])); err != nil {
+		return
+	}
+	_, _ = conn.Write([]byte{5, 2})
+
+	// username and password authentication
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return
+	}
+	user := make([]byte, header[1])
+	_, _ = io.ReadFull(conn, user)
+	_, _ = io.ReadFull(conn, header[:1])
+	pass := make([]byte, header[0])
+	_, _ = io.ReadFull(conn, pass)
+	if string(user) != username || string(pass) != password {
+		_, _ = conn.Write([]byte{1, 1})
+		return
+	}
+	_, _ = conn.Write([]byte{1, 0})
+
+	// connect request: version, command, reserved, address type, address, port
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(conn, request); err != nil {
+		return
+	}
+
+	var host string
+	switch request[3] {
+	case 1:
+		ip := make([]byte, 4)
+		_, _ = io.ReadFull(conn, ip)
+		host = net.IP(ip).String()
+	case 3:
+		_, _ = io.ReadFull(conn, header[:1])
+		name := make([]byte, header[0])
+		_, _ = io.ReadFull(conn, name)
+		host = string(name)
+	default:
+		return
+	}
+
+	port := make([]byte, 2)
+	_, _ = io.ReadFull(conn, port)
+	if host == "do.test" {
+		host = "127.0.0.1"
+	}
+
+	upstream, err := net.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))))
+	if err != nil {
+		_, _ = conn.Write([]byte{5, 5, 0, 1, 0, 0, 0, 0, 0, 0})
+		return
+	}
+	defer upstream.Close()
+	_, _ = conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0})
+
+	go func() {
+		_, _ = io.Copy(upstream, conn)
+	}()
+	_, _ = io.Copy(conn, upstream)
+}
@@ -0,0 +1,122 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"time"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+// defaultUserAgent is the User-Agent sent by net/http when the request does not have one
+const defaultUserAgent = "Go-http-client/1.1"
+
+// exchange defines a request sent by the transport and the response received
+type exchange struct {
+	request  *http.Request
+	response *http.Response
+	timer    *timer
+}
+
+// recorder is a http.RoundTripper that keeps every request sent through it,
+// including the ones made to follow redirects
+type recorder struct {
+	next      http.RoundTripper
+	exchanges []exchange
+}
+
+func newRecorder(next http.RoundTripper) *recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &recorder{next: next}
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	t := newTimer()
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), t.trace()))
+
+	res, err := r.next.RoundTrip(req)
+	r.exchanges = append(r.exchanges, exchange{request: req, response: res, timer: t})
+
+	return res, err
+}
+
+// timings returns the timings of the last round trip given the moment its body was read
+func (r *recorder) timings(end time.Time) types.Timings {
+	if len(r.exchanges) == 0 {
+		return types.Timings{Blocked: -1, DNS: -1, Connect: -1, SSL: -1, Send: -1, Wait: -1, Receive: -1}
+	}
+
+	return r.exchanges[len(r.exchanges)-1].timer.timings(end)
+}
+
+// sent returns the last request sent and the redirects followed to reach it, including the
+// redirect of the last request when it was not followed because the client failed.
+// The original request is used when nothing was sent.
+func (r *recorder) sent(original *http.Request, body string, failed bool) *types.Request {
+	last := original
+	startedAt := time.Now()
+	redirects := make([]types.Redirect, 0)
+
+	for i, ex := range r.exchanges {
+		last, startedAt = ex.request, ex.timer.start
+		if (i == len(r.exchanges)-1 && !failed) || ex.response == nil {
+			continue
+		}
+
+		redirects = append(redirects, types.Redirect{
+			StatusCode: ex.response.StatusCode,
+			URL:        ex.request.URL.String(),
+			Location:   ex.response.Header.Get("Location"),
+		})
+	}
+
+	if last != original && last.ContentLength == 0 {
+		// the body is dropped when following 301, 302 and 303 redirects
+		body = ""
+	}
+
+	return &types.Request{
+		Method:    last.Method,
+		URL:       last.URL.String(),
+		Headers:   r.headers(last),
+		Body:      body,
+		Redirects: redirects,
+		StartedAt: startedAt,
+	}
+}
+
+// headers returns the headers of the request including the ones added by the transport
+func (r *recorder) headers(req *http.Request) map[string]interface{} {
+	headers := make(map[string]interface{})
+	for key, value := range req.Header {
+		headers[key] = value
+	}
+
+	if req.Header.Get("Host") == "" {
+		host := req.Host
+		if host == "" {
+			host = req.URL.Host
+		}
+		headers["Host"] = []string{host}
+	}
+
+	if req.Header.Get("User-Agent") == "" {
+		headers["User-Agent"] = []string{defaultUserAgent}
+	}
+
+	if req.ContentLength > 0 {
+		headers["Content-Length"] = []string{strconv.FormatInt(req.ContentLength, 10)}
+	}
+
+	transport, ok := r.next.(*http.Transport)
+	if ok && !transport.DisableCompression && req.Method != http.MethodHead &&
+		req.Header.Get("Accept-Encoding") == "" && req.Header.Get("Range") == "" {
+		headers["Accept-Encoding"] = []string{"gzip"}
+	}
+
+	return headers
+}
@@ -2,31 +2,46 @@ package request
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/http/httputil"
+	neturl "net/url"
 	"os"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/jibaru/do/internal/cookies"
+	"github.com/jibaru/do/internal/oauth2"
 	"github.com/jibaru/do/internal/types"
+	"github.com/jibaru/do/internal/utils"
 )
 
 type HttpClient interface {
-	Do(doFile types.DoFile) (*types.Response, error)
+	// Do sends the request described by the do file and returns the request that was
+	// actually sent, which is also returned when sending fails.
+	Do(doFile types.DoFile) (*types.Request, *types.Response, error)
 }
 
 type httpClient struct {
 	client *http.Client
+	tokens oauth2.TokenSource
 }
 
-func NewHttpClient(client *http.Client) HttpClient {
+// NewHttpClient returns a client that sends the requests with the http client, getting the
+// tokens of oauth2 auth from the token source
+func NewHttpClient(client *http.Client, tokens oauth2.TokenSource) HttpClient {
 	return &httpClient{
 		client,
+		tokens,
 	}
 }
 
-func (h *httpClient) Do(doFile types.DoFile) (*types.Response, error) {
+// URL returns the url described by the do file, replacing the params and encoding the query
+func URL(doFile types.DoFile) (string, error) {
 	// Replace params
 	url := string(doFile.Do.URL)
 	for key, value := range doFile.Do.Params {
@@ -35,84 +50,311 @@ func (h *httpClient) Do(doFile types.DoFile) (*types.Response, error) {
 		afterReplaceUrl := strings.Replace(url, placeholder, fmt.Sprintf("%v", value), -1)
 
 		if beforeReplaceUrl == afterReplaceUrl {
-			return nil, NewCanNotReplaceParamError(key)
+			return "", NewCanNotReplaceParamError(key)
 		}
 
 		url = afterReplaceUrl
 	}
 
-	req, err := http.NewRequest(string(doFile.Do.Method), url, nil)
-	if err != nil {
-		return nil, err
+	if len(doFile.Do.Query) == 0 {
+		return url, nil
 	}
 
-	for key, value := range doFile.Do.Headers {
-		req.Header.Add(key, fmt.Sprintf("%v", value))
+	parsedURL, err := neturl.Parse(url)
+	if err != nil {
+		return "", err
 	}
 
-	query := req.URL.Query()
+	query := parsedURL.Query()
 	for key, value := range doFile.Do.Query {
 		query.Add(key, fmt.Sprintf("%v", value))
 	}
-	req.URL.RawQuery = query.Encode()
+	parsedURL.RawQuery = query.Encode()
+
+	return parsedURL.String(), nil
+}
+
+// Build creates the http request described by the do file, replacing the params,
+// encoding the query and the body, and signs it for sigv4 auth
+func Build(doFile types.DoFile) (*http.Request, error) {
+	url, err := URL(doFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var body io.Reader
+	contentType := ""
 
 	if doFile.Do.Body != nil {
 		switch doFile.Do.Body.(type) {
 		case types.String:
 			val := doFile.Do.Body.(types.String)
-			req.Body = io.NopCloser(strings.NewReader(string(val)))
+			body = strings.NewReader(string(val))
 		case types.Map:
 			// Map equals to multipart/form-data
-			var requestBody bytes.Buffer
-			writer := multipart.NewWriter(&requestBody)
-
-			for key, value := range doFile.Do.Body.(types.Map) {
-				switch value.(type) {
-				case types.String:
-					val := value.(types.String)
-					err = writer.WriteField(key, string(val))
-					if err != nil {
-						return nil, NewCanNotDoRequestError(err)
-					}
-				case types.File:
-					typeFile := value.(types.File)
-
-					file, err := os.Open(typeFile.Path)
-					if err != nil {
-						return nil, NewCanNotDoRequestError(err)
-					}
-					part, err := writer.CreateFormFile(key, file.Name())
-					if err != nil {
-						return nil, NewCanNotDoRequestError(err)
-					}
-
-					_, err = io.Copy(part, file)
-					if err != nil {
-						return nil, NewCanNotDoRequestError(err)
-					}
+			requestBody, formDataContentType, err := buildMultipartBody(doFile.Do.Body.(types.Map))
+			if err != nil {
+				return nil, err
+			}
+
+			body = requestBody
+			contentType = formDataContentType
+		}
+	}
+
+	req, err := http.NewRequest(string(doFile.Do.Method), url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range doFile.Do.Headers {
+		req.Header.Add(key, fmt.Sprintf("%v", value))
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	if auth := doFile.Do.Auth; auth != nil {
+		switch auth.Type {
+		case types.AuthBasic:
+			req.SetBasicAuth(auth.Username, auth.Password)
+		case types.AuthBearer:
+			req.Header.Set("Authorization", "Bearer "+auth.Token)
+		case types.AuthSigV4:
+			if err = signV4(req, *auth, time.Now()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return req, nil
+}
+
+// Dump returns the request as it is sent on the wire
+func Dump(req *http.Request) (string, error) {
+	dump, err := httputil.DumpRequestOut(req, true)
+	if err != nil {
+		return "", NewCanNotDumpRequestError(err)
+	}
+
+	return string(dump), nil
+}
+
+// describeBody returns the body as it is sent, or a summary of the parts for multipart bodies
+func describeBody(body interface{}) string {
+	switch val := body.(type) {
+	case types.String:
+		return string(val)
+	case types.Map:
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		parts := make([]string, 0, len(keys))
+		for _, key := range keys {
+			switch value := val[key].(type) {
+			case types.String:
+				parts = append(parts, key+"="+string(value))
+			case types.File:
+				part := key + "=@" + value.Path
+				if info, err := os.Stat(value.Path); err == nil {
+					part += fmt.Sprintf(" (%d bytes)", info.Size())
 				}
+				parts = append(parts, part)
 			}
+		}
+
+		return "multipart/form-data: " + strings.Join(parts, "; ")
+	}
+
+	return ""
+}
+
+// formFields returns the parts of a multipart body sorted by name, nil for other bodies
+func formFields(body interface{}) []types.FormField {
+	values, ok := body.(types.Map)
+	if !ok {
+		return nil
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fields := make([]types.FormField, 0, len(keys))
+	for _, key := range keys {
+		switch value := values[key].(type) {
+		case types.String:
+			fields = append(fields, types.FormField{Name: key, Value: string(value)})
+		case types.File:
+			fields = append(fields, types.FormField{Name: key, FileName: value.Path})
+		}
+	}
+
+	return fields
+}
 
-			err = writer.Close()
+func buildMultipartBody(values types.Map) (*bytes.Buffer, string, error) {
+	var requestBody bytes.Buffer
+	writer := multipart.NewWriter(&requestBody)
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		switch value := values[key].(type) {
+		case types.String:
+			err := writer.WriteField(key, string(value))
 			if err != nil {
-				return nil, NewCanNotDoRequestError(err)
+				return nil, "", NewCanNotDoRequestError(err)
 			}
+		case types.File:
+			err := writeFormFile(writer, key, value)
+			if err != nil {
+				return nil, "", NewCanNotDoRequestError(err)
+			}
+		}
+	}
+
+	err := writer.Close()
+	if err != nil {
+		return nil, "", NewCanNotDoRequestError(err)
+	}
+
+	return &requestBody, writer.FormDataContentType(), nil
+}
+
+func writeFormFile(writer *multipart.Writer, key string, typeFile types.File) error {
+	file, err := os.Open(typeFile.Path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	part, err := writer.CreateFormFile(key, file.Name())
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(part, file)
+	return err
+}
 
-			req.Header.Set("Content-Type", writer.FormDataContentType())
-			req.Body = io.NopCloser(&requestBody)
+func (h *httpClient) Do(doFile types.DoFile) (*types.Request, *types.Response, error) {
+	options := doFile.Options
+	client, err := withOptions(h.client, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if auth := doFile.Do.Auth; auth != nil && auth.Type == types.AuthOAuth2 {
+		if h.tokens == nil {
+			return nil, nil, NewCanNotDoRequestError(errors.New("oauth2 auth is not available"))
 		}
+
+		// the token endpoint is requested with the timeouts, tls and proxy of the options, without the cookies
+		tokenClient := *client
+		tokenClient.Jar = nil
+		token, err := h.tokens.WithClient(&tokenClient).Token(*auth)
+		if err != nil {
+			return nil, nil, err
+		}
+		doFile.Do.Auth = &types.Auth{Type: types.AuthBearer, Token: token}
 	}
+	attempts := make([]types.Attempt, 0)
+
+	for i := 0; ; i++ {
+		startedAt := time.Now()
+		sent, response, err := send(client, doFile)
+		if sent == nil {
+			// the request could not be built, trying again gives the same result
+			return nil, nil, err
+		}
 
-	res, err := h.client.Do(req)
+		attempt := types.Attempt{StartedAt: startedAt, Duration: milliseconds(startedAt, time.Now())}
+		if response != nil {
+			attempt.StatusCode = response.StatusCode
+		}
+		if err != nil {
+			attempt.Error = utils.Ptr(err.Error())
+		}
+
+		if i < options.Retries && shouldRetry(options, response, err) {
+			delay := backoff(options, i)
+			attempt.Backoff = float64(delay.Microseconds()) / 1000
+			attempts = append(attempts, attempt)
+			time.Sleep(delay)
+			continue
+		}
+
+		sent.Attempts = append(attempts, attempt)
+		return sent, response, err
+	}
+}
+
+// send does the request once, answering the challenge of the server for digest auth.
+// The request sent is nil when it can not be built.
+func send(client *http.Client, doFile types.DoFile) (*types.Request, *types.Response, error) {
+	sent, response, err := sendWithAuthorization(client, doFile, "")
+	if err != nil || doFile.Do.Auth == nil || doFile.Do.Auth.Type != types.AuthDigest ||
+		response.StatusCode != http.StatusUnauthorized {
+		return sent, response, err
+	}
+
+	challenge, ok := parseDigestChallenge(response.Headers)
+	if !ok {
+		return sent, response, err
+	}
+
+	authorization, err := digestAuthorization(*doFile.Do.Auth, challenge, sent.Method, sent.URL)
 	if err != nil {
-		return nil, NewCanNotDoRequestError(err)
+		return sent, response, err
+	}
+
+	return sendWithAuthorization(client, doFile, authorization)
+}
+
+// sendWithAuthorization does the request once with the Authorization header when it is not empty
+func sendWithAuthorization(client *http.Client, doFile types.DoFile, authorization string) (*types.Request, *types.Response, error) {
+	req, err := Build(doFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	rec := newRecorder(client.Transport)
+	recorded := *client
+	recorded.Transport = rec
+
+	res, err := recorded.Do(req)
+	sent := rec.sent(req, describeBody(doFile.Do.Body), err != nil)
+	if sent.Body != "" {
+		sent.Form = formFields(doFile.Do.Body)
+	}
+	if doFile.Do.Auth != nil {
+		maskAuthorization(sent.Headers)
+	}
+	if err != nil {
+		return sent, nil, NewCanNotDoRequestError(err)
 	}
 	defer res.Body.Close()
 
 	respBody, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, NewCanNotReadResponseBodyError(err)
+		return sent, nil, NewCanNotReadResponseBodyError(err)
 	}
+	timings := rec.timings(time.Now())
 
 	// Get response headers
 	headers := make(map[string]interface{})
@@ -120,9 +362,18 @@ func (h *httpClient) Do(doFile types.DoFile) (*types.Response, error) {
 		headers[key] = value
 	}
 
-	return &types.Response{
+	setCookies := make([]types.Cookie, 0)
+	for _, cookie := range res.Cookies() {
+		setCookies = append(setCookies, cookies.FromHTTP(cookie))
+	}
+
+	return sent, &types.Response{
 		StatusCode: res.StatusCode,
 		Body:       string(respBody),
 		Headers:    headers,
+		Proto:      res.Proto,
+		Cookies:    setCookies,
+		TLS:        tlsSummary(res.TLS),
+		Timings:    timings,
 	}, nil
 }
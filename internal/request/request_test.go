@@ -0,0 +1,545 @@
+package request_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jibaru/do/internal/request"
+	"github.com/jibaru/do/internal/types"
+	"github.com/jibaru/do/internal/utils"
+)
+
+func TestBuild(t *testing.T) {
+	testCases := []struct {
+		name                string
+		doFile              types.DoFile
+		expectedMethod      string
+		expectedURL         string
+		expectedHeaders     map[string]string
+		expectedBodyContain []string
+		expectedError       error
+	}{
+		{
+			name: "success string body",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method: "POST",
+					URL:    "http://localhost:8080/users/:id",
+					Params: types.Map{"id": types.Int(12)},
+					Query:  types.Map{"q": types.String("a b&c"), "active": types.Bool(true)},
+					Headers: types.Map{
+						"Content-Type": types.String("application/json"),
+					},
+					Body: types.String(`{"name":"john"}`),
+				},
+			},
+			expectedMethod: "POST",
+			expectedURL:    "http://localhost:8080/users/12?active=true&q=a+b%26c",
+			expectedHeaders: map[string]string{
+				"Content-Type": "application/json",
+			},
+			expectedBodyContain: []string{`{"name":"john"}`},
+		},
+		{
+			name: "success multipart body",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method: "POST",
+					URL:    "http://localhost:8080/upload",
+					Headers: types.Map{
+						"Content-Type": types.String("multipart/form-data"),
+					},
+					Body: types.Map{
+						"name": types.String("john"),
+						"file": types.File{Path: "testdata/upload.txt"},
+					},
+				},
+			},
+			expectedMethod: "POST",
+			expectedURL:    "http://localhost:8080/upload",
+			expectedHeaders: map[string]string{
+				"Content-Type": "multipart/form-data; boundary=",
+			},
+			expectedBodyContain: []string{
+				`Content-Disposition: form-data; name="file"; filename="testdata/upload.txt"`,
+				"file content",
+				`Content-Disposition: form-data; name="name"`,
+				"john",
+			},
+		},
+		{
+			name: "error param not found in url",
+			doFile: types.DoFile{
+				Do: types.Do{
+					Method: "GET",
+					URL:    "http://localhost:8080/users",
+					Params: types.Map{"id": types.Int(12)},
+				},
+			},
+			expectedError: errors.New("can not replace param: id"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := request.Build(tc.doFile)
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if req == nil {
+				return
+			}
+
+			if req.Method != tc.expectedMethod {
+				t.Errorf("expected method %v, got %v", tc.expectedMethod, req.Method)
+			}
+
+			if req.URL.String() != tc.expectedURL {
+				t.Errorf("expected url %v, got %v", tc.expectedURL, req.URL.String())
+			}
+
+			for key, value := range tc.expectedHeaders {
+				if !strings.HasPrefix(req.Header.Get(key), value) {
+					t.Errorf("expected header %v: %v, got %v", key, value, req.Header.Get(key))
+				}
+			}
+
+			body, _ := io.ReadAll(req.Body)
+			for _, value := range tc.expectedBodyContain {
+				if !strings.Contains(string(body), value) {
+					t.Errorf("expected body to contain %v, got %v", value, string(body))
+				}
+			}
+		})
+	}
+}
+
+func TestHttpClient_Do_Form(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := request.NewHttpClient(&http.Client{}, nil)
+	sent, _, err := client.Do(types.DoFile{
+		Do: types.Do{
+			Method:  "POST",
+			URL:     types.String(server.URL + "/upload"),
+			Headers: types.Map{"Content-Type": types.String("multipart/form-data")},
+			Body: types.Map{
+				"name": types.String("john"),
+				"file": types.File{Path: "testdata/upload.txt"},
+				"age":  types.Int(30),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := []types.FormField{
+		{Name: "file", FileName: "testdata/upload.txt"},
+		{Name: "name", Value: "john"},
+	}
+	if !reflect.DeepEqual(sent.Form, expected) {
+		t.Errorf("expected form %+v, got %+v", expected, sent.Form)
+	}
+}
+
+func TestHttpClient_Do(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/start", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/end?from=start", http.StatusFound)
+	})
+	mux.HandleFunc("/end", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc", Path: "/", HttpOnly: true, SameSite: http.SameSiteLaxMode})
+		_, _ = w.Write([]byte("done " + r.Header.Get("X-Token")))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := request.NewHttpClient(&http.Client{}, nil)
+	sent, response, err := client.Do(types.DoFile{
+		Do: types.Do{
+			Method:  "GET",
+			URL:     types.String(server.URL + "/:path"),
+			Params:  types.Map{"path": types.String("start")},
+			Headers: types.Map{"X-Token": types.String("secret")},
+			Body:    types.String("hello"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if response.StatusCode != http.StatusOK || response.Body != "done secret" {
+		t.Errorf("expected 200 done secret, got %v %v", response.StatusCode, response.Body)
+	}
+
+	expectedRedirects := []types.Redirect{
+		{
+			StatusCode: http.StatusFound,
+			URL:        server.URL + "/start",
+			Location:   "/end?from=start",
+		},
+	}
+	if !reflect.DeepEqual(sent.Redirects, expectedRedirects) {
+		t.Errorf("expected redirects %v, got %v", expectedRedirects, sent.Redirects)
+	}
+
+	if sent.URL != server.URL+"/end?from=start" {
+		t.Errorf("expected url %v, got %v", server.URL+"/end?from=start", sent.URL)
+	}
+
+	if sent.Body != "" {
+		t.Errorf("expected body to be dropped after redirect, got %v", sent.Body)
+	}
+
+	if sent.StartedAt.IsZero() {
+		t.Errorf("expected the start time of the request")
+	}
+
+	if response.Timings.Wait < 0 || response.Timings.Receive < 0 || response.Timings.SSL != -1 {
+		t.Errorf("expected wait and receive timings without ssl, got %+v", response.Timings)
+	}
+
+	expectedCookies := []types.Cookie{{Name: "session", Value: "abc", Path: "/", HttpOnly: true, SameSite: "Lax"}}
+	if !reflect.DeepEqual(response.Cookies, expectedCookies) {
+		t.Errorf("expected cookies %+v, got %+v", expectedCookies, response.Cookies)
+	}
+
+	for _, key := range []string{"X-Token", "Host", "User-Agent"} {
+		if _, ok := sent.Headers[key]; !ok {
+			t.Errorf("expected header %v in %v", key, sent.Headers)
+		}
+	}
+}
+
+func TestHttpClient_Do_Options(t *testing.T) {
+	failures := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/flaky", func(w http.ResponseWriter, r *http.Request) {
+		if failures < 2 {
+			failures++
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	closed := httptest.NewServer(mux)
+	closed.Close()
+
+	testCases := []struct {
+		name                string
+		url                 string
+		options             types.Options
+		expectedStatusCodes []int
+		expectedError       bool
+	}{
+		{
+			name:                "success retry on status code",
+			url:                 server.URL + "/flaky",
+			options:             types.Options{Retries: 3, RetryOn: []int{503}, Backoff: types.Duration(time.Millisecond)},
+			expectedStatusCodes: []int{503, 503, 200},
+		},
+		{
+			name:                "success status code not retried",
+			url:                 server.URL + "/flaky",
+			options:             types.Options{Retries: 3, RetryOn: []int{502}},
+			expectedStatusCodes: []int{200},
+		},
+		{
+			name:                "error timeout",
+			url:                 server.URL + "/slow",
+			options:             types.Options{Timeout: types.Duration(50 * time.Millisecond)},
+			expectedStatusCodes: []int{0},
+			expectedError:       true,
+		},
+		{
+			name:                "error response timeout",
+			url:                 server.URL + "/slow",
+			options:             types.Options{ResponseTimeout: types.Duration(50 * time.Millisecond)},
+			expectedStatusCodes: []int{0},
+			expectedError:       true,
+		},
+		{
+			name: "error retry on network error",
+			url:  closed.URL,
+			options: types.Options{
+				Retries:             2,
+				RetryOnNetworkError: true,
+				Backoff:             types.Duration(time.Millisecond),
+				MaxBackoff:          types.Duration(2 * time.Millisecond),
+			},
+			expectedStatusCodes: []int{0, 0, 0},
+			expectedError:       true,
+		},
+		{
+			name: "error retry on timeout",
+			url:  server.URL + "/slow",
+			options: types.Options{
+				Timeout:             types.Duration(50 * time.Millisecond),
+				Retries:             1,
+				RetryOnNetworkError: true,
+				Backoff:             types.Duration(time.Millisecond),
+			},
+			expectedStatusCodes: []int{0, 0},
+			expectedError:       true,
+		},
+		{
+			name: "error redirect loop not retried",
+			url:  server.URL + "/loop",
+			options: types.Options{
+				MaxRedirects:        2,
+				Retries:             2,
+				RetryOnNetworkError: true,
+				Backoff:             types.Duration(time.Millisecond),
+			},
+			expectedStatusCodes: []int{0},
+			expectedError:       true,
+		},
+	}
+
+	client := request.NewHttpClient(&http.Client{}, nil)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sent, _, err := client.Do(types.DoFile{
+				Do:      types.Do{Method: "GET", URL: types.String(tc.url)},
+				Options: tc.options,
+			})
+
+			if err != nil && !tc.expectedError {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError {
+				t.Errorf("expected error, got no error")
+			}
+
+			statusCodes := make([]int, 0, len(sent.Attempts))
+			for i, attempt := range sent.Attempts {
+				statusCodes = append(statusCodes, attempt.StatusCode)
+
+				if (attempt.Error != nil) != tc.expectedError {
+					t.Errorf("expected error in attempt %v to be %v, got %v", i, tc.expectedError, attempt.Error)
+				}
+
+				if last := i == len(sent.Attempts)-1; last != (attempt.Backoff == 0) {
+					t.Errorf("expected backoff only before a retry, got %v in attempt %v", attempt.Backoff, i)
+				}
+			}
+
+			if !reflect.DeepEqual(statusCodes, tc.expectedStatusCodes) {
+				t.Errorf("expected status codes %v, got %v", tc.expectedStatusCodes, statusCodes)
+			}
+		})
+	}
+}
+
+func TestHttpClient_Do_Redirects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/echo", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	other := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	mux.HandleFunc("/first", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/second", http.StatusFound)
+	})
+	mux.HandleFunc("/second", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, other+"/echo", http.StatusMovedPermanently)
+	})
+
+	testCases := []struct {
+		name              string
+		options           types.Options
+		expectedLocations []string
+		expectedStatus    int
+		expectedBody      string
+		expectedError     error
+	}{
+		{
+			name:              "success follow and drop authorization across hosts",
+			expectedLocations: []string{"/second", other + "/echo"},
+			expectedStatus:    http.StatusOK,
+			expectedBody:      "",
+		},
+		{
+			name:              "success keep authorization across hosts",
+			options:           types.Options{KeepAuthorization: true},
+			expectedLocations: []string{"/second", other + "/echo"},
+			expectedStatus:    http.StatusOK,
+			expectedBody:      "Bearer secret",
+		},
+		{
+			name:              "success redirects disabled",
+			options:           types.Options{FollowRedirects: utils.Ptr(false)},
+			expectedLocations: []string{},
+			expectedStatus:    http.StatusFound,
+			expectedBody:      "<a href=\"/second\">Found</a>.\n\n",
+		},
+		{
+			name:              "error too many redirects",
+			options:           types.Options{MaxRedirects: 1},
+			expectedLocations: []string{"/second", other + "/echo"},
+			expectedError: request.NewCanNotDoRequestError(&url.Error{
+				Op:  "Get",
+				URL: other + "/echo",
+				Err: request.NewTooManyRedirectsError(1),
+			}),
+		},
+	}
+
+	client := request.NewHttpClient(&http.Client{}, nil)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sent, response, err := client.Do(types.DoFile{
+				Do: types.Do{
+					Method:  "GET",
+					URL:     types.String(server.URL + "/first"),
+					Headers: types.Map{"Authorization": types.String("Bearer secret")},
+				},
+				Options: tc.options,
+			})
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			locations := make([]string, 0, len(sent.Redirects))
+			for _, redirect := range sent.Redirects {
+				locations = append(locations, redirect.Location)
+			}
+			if !reflect.DeepEqual(locations, tc.expectedLocations) {
+				t.Errorf("expected locations %v, got %v", tc.expectedLocations, locations)
+			}
+
+			if tc.expectedError != nil {
+				return
+			}
+
+			if response.StatusCode != tc.expectedStatus || response.Body != tc.expectedBody {
+				t.Errorf("expected %v %q, got %v %q", tc.expectedStatus, tc.expectedBody, response.StatusCode, response.Body)
+			}
+		})
+	}
+}
+
+func TestHttpClient_Do_TLS(t *testing.T) {
+	clientCert, err := os.ReadFile("testdata/client.crt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(clientCert)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) > 0 {
+			_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+		}
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.VerifyClientCertIfGiven, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err = os.WriteFile(caPath, caPEM, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name          string
+		options       types.Options
+		expectedBody  string
+		expectedError bool
+	}{
+		{
+			name:    "success custom ca",
+			options: types.Options{CACert: caPath, TLSMinVersion: "1.2"},
+		},
+		{
+			name:    "success insecure",
+			options: types.Options{Insecure: true},
+		},
+		{
+			name:         "success client certificate",
+			options:      types.Options{CACert: caPath, ClientCert: "testdata/client.crt", ClientKey: "testdata/client.key"},
+			expectedBody: "do client",
+		},
+		{
+			name:          "error unknown authority",
+			options:       types.Options{},
+			expectedError: true,
+		},
+		{
+			name:          "error unsupported tls version",
+			options:       types.Options{Insecure: true, TLSMinVersion: "2.0"},
+			expectedError: true,
+		},
+	}
+
+	client := request.NewHttpClient(&http.Client{}, nil)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, response, err := client.Do(types.DoFile{
+				Do:      types.Do{Method: "GET", URL: types.String(server.URL)},
+				Options: tc.options,
+			})
+
+			if err != nil && !tc.expectedError {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError {
+				t.Errorf("expected error, got no error")
+			}
+
+			if tc.expectedError {
+				return
+			}
+
+			if response.Body != tc.expectedBody {
+				t.Errorf("expected body %q, got %q", tc.expectedBody, response.Body)
+			}
+
+			if response.TLS == nil || response.TLS.Version != "TLS 1.3" || response.TLS.CipherSuite == "" ||
+				response.TLS.PeerCertificate.Subject != "O=Acme Co" {
+				t.Errorf("expected the negotiated tls, got %+v", response.TLS)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package request
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+const (
+	// sigV4Algorithm is the signing algorithm written in the Authorization header
+	sigV4Algorithm = "AWS4-HMAC-SHA256"
+	// sigV4DateFormat is the format of the X-Amz-Date header
+	sigV4DateFormat = "20060102T150405Z"
+)
+
+// signV4 signs the request with AWS Signature Version 4 using the time of the X-Amz-Date header,
+// or the given time when it is not defined. S3 paths are encoded once and its payload hash is
+// sent in the X-Amz-Content-Sha256 header, as S3 requires.
+func signV4(req *http.Request, auth types.Auth, now time.Time) error {
+	payload, err := payloadHash(req)
+	if err != nil {
+		return NewCanNotSignRequestError(err)
+	}
+
+	date := now.UTC()
+	if value := req.Header.Get("X-Amz-Date"); value != "" {
+		if date, err = time.Parse(sigV4DateFormat, value); err != nil {
+			return NewCanNotSignRequestError(fmt.Errorf("invalid X-Amz-Date %q", value))
+		}
+	}
+
+	req.Header.Del("Authorization")
+	req.Header.Set("X-Amz-Date", date.Format(sigV4DateFormat))
+	if auth.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", auth.SessionToken)
+	}
+	if auth.Service == "s3" {
+		req.Header.Set("X-Amz-Content-Sha256", payload)
+	}
+
+	headers, signedHeaders := canonicalHeaders(req)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalPath(req.URL, auth.Service),
+		canonicalQuery(req.URL),
+		headers,
+		signedHeaders,
+		payload,
+	}, "\n")
+
+	day := date.Format("20060102")
+	scope := strings.Join([]string{day, auth.Region, auth.Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{sigV4Algorithm, date.Format(sigV4DateFormat), scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+auth.SecretKey), day)
+	for _, part := range []string{auth.Region, auth.Service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigV4Algorithm, auth.AccessKey, scope, signedHeaders, signature))
+
+	return nil
+}
+
+// payloadHash returns the hex encoded sha256 of the body without consuming it
+func payloadHash(req *http.Request) (string, error) {
+	if req.Body == nil || req.GetBody == nil {
+		return sha256Hex(nil), nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+
+	return sha256Hex(content), nil
+}
+
+// canonicalPath returns the path with each segment uri encoded, twice for every service except S3
+func canonicalPath(u *url.URL, service string) string {
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+
+	path = uriEncode(path, false)
+	if service != "s3" {
+		path = uriEncode(path, false)
+	}
+
+	return path
+}
+
+// canonicalQuery returns the query parameters uri encoded and sorted by key and value
+func canonicalQuery(u *url.URL) string {
+	query, _ := url.ParseQuery(u.RawQuery)
+
+	params := make([]string, 0, len(query))
+	for key, values := range query {
+		for _, value := range values {
+			params = append(params, uriEncode(key, true)+"="+uriEncode(value, true))
+		}
+	}
+	sort.Strings(params)
+
+	return strings.Join(params, "&")
+}
+
+// canonicalHeaders returns the signed headers, the host and every header of the request, written
+// in lowercase with their values trimmed, and the list of their names
+func canonicalHeaders(req *http.Request) (string, string) {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	values := map[string]string{"host": host}
+	for key, vals := range req.Header {
+		name := strings.ToLower(key)
+		if name == "authorization" || name == "host" {
+			continue
+		}
+
+		trimmed := make([]string, 0, len(vals))
+		for _, val := range vals {
+			trimmed = append(trimmed, strings.Join(strings.Fields(val), " "))
+		}
+		values[name] = strings.Join(trimmed, ",")
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	canonical := strings.Builder{}
+	for _, name := range names {
+		canonical.WriteString(name + ":" + values[name] + "\n")
+	}
+
+	return canonical.String(), strings.Join(names, ";")
+}
+
+// uriEncode encodes every byte except the unreserved characters, and the slash when it is not a query value
+func uriEncode(value string, encodeSlash bool) string {
+	encoded := strings.Builder{}
+	for _, b := range []byte(value) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9',
+			b == '-', b == '_', b == '.', b == '~', b == '/' && !encodeSlash:
+			encoded.WriteByte(b)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+
+	return encoded.String()
+}
+
+func sha256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, value string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
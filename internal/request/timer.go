@@ -0,0 +1,91 @@
+package request
+
+import (
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+// timer keeps the moments when each phase of a round trip starts and ends
+type timer struct {
+	mu           sync.Mutex
+	start        time.Time
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	tlsStart     time.Time
+	tlsDone      time.Time
+	gotConn      time.Time
+	wroteRequest time.Time
+	firstByte    time.Time
+}
+
+func newTimer() *timer {
+	return &timer{start: time.Now()}
+}
+
+// trace returns the hooks that fill the timer while the request is sent
+func (t *timer) trace() *httptrace.ClientTrace {
+	mark := func(moment *time.Time) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if moment.IsZero() {
+			*moment = time.Now()
+		}
+	}
+
+	return &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { mark(&t.dnsStart) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { mark(&t.dnsDone) },
+		ConnectStart:         func(string, string) { mark(&t.connectStart) },
+		ConnectDone:          func(string, string, error) { mark(&t.connectDone) },
+		TLSHandshakeStart:    func() { mark(&t.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { mark(&t.tlsDone) },
+		GotConn:              func(httptrace.GotConnInfo) { mark(&t.gotConn) },
+		WroteRequest:         func(httptrace.WroteRequestInfo) { mark(&t.wroteRequest) },
+		GotFirstResponseByte: func() { mark(&t.firstByte) },
+	}
+}
+
+// timings returns the duration of each phase given the moment the body was read.
+// Phases that did not happen, like dns and connect on reused connections, are -1.
+func (t *timer) timings(end time.Time) types.Timings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	blockedEnd := t.gotConn
+	for _, moment := range []time.Time{t.connectStart, t.dnsStart} {
+		if !moment.IsZero() {
+			blockedEnd = moment
+		}
+	}
+
+	connectDone := t.connectDone
+	if !t.tlsDone.IsZero() {
+		// the connect time includes the tls handshake
+		connectDone = t.tlsDone
+	}
+
+	return types.Timings{
+		Blocked: milliseconds(t.start, blockedEnd),
+		DNS:     milliseconds(t.dnsStart, t.dnsDone),
+		Connect: milliseconds(t.connectStart, connectDone),
+		SSL:     milliseconds(t.tlsStart, t.tlsDone),
+		Send:    milliseconds(t.gotConn, t.wroteRequest),
+		Wait:    milliseconds(t.wroteRequest, t.firstByte),
+		Receive: milliseconds(t.firstByte, end),
+	}
+}
+
+// milliseconds returns the duration between the moments, or -1 when one of them did not happen
+func milliseconds(from, to time.Time) float64 {
+	if from.IsZero() || to.IsZero() {
+		return -1
+	}
+
+	return float64(to.Sub(from).Microseconds()) / 1000
+}
@@ -0,0 +1,104 @@
+package request
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+// tlsVersions defines the versions accepted as minimum tls version
+var tlsVersions = map[string]uint16{
+	"1":   tls.VersionTLS10,
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// hasTLSOptions returns true when the options change the tls configuration
+func hasTLSOptions(options types.Options) bool {
+	return options.CACert != "" || options.ClientCert != "" || options.ClientKey != "" ||
+		options.TLSMinVersion != "" || options.TLSServerName != "" || options.Insecure
+}
+
+// tlsConfig returns the tls configuration described by the options
+func tlsConfig(options types.Options) (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName:         options.TLSServerName,
+		InsecureSkipVerify: options.Insecure,
+	}
+
+	if options.TLSMinVersion != "" {
+		version, ok := tlsVersions[options.TLSMinVersion]
+		if !ok {
+			return nil, NewCanNotConfigureTLSError(fmt.Errorf("unsupported tls version %s", options.TLSMinVersion))
+		}
+		config.MinVersion = version
+	}
+
+	if options.CACert != "" {
+		pem, err := os.ReadFile(options.CACert)
+		if err != nil {
+			return nil, NewCanNotConfigureTLSError(err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, NewCanNotConfigureTLSError(fmt.Errorf("no certificates found in %s", options.CACert))
+		}
+		config.RootCAs = pool
+	}
+
+	if options.ClientCert != "" || options.ClientKey != "" {
+		if options.ClientCert == "" {
+			return nil, NewCanNotConfigureTLSError(errors.New("client_key requires client_cert"))
+		}
+
+		// the key can be in the same file as the certificate
+		key := options.ClientKey
+		if key == "" {
+			key = options.ClientCert
+		}
+
+		certificate, err := tls.LoadX509KeyPair(options.ClientCert, key)
+		if err != nil {
+			return nil, NewCanNotConfigureTLSError(err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	return config, nil
+}
+
+// tlsSummary returns the negotiated connection, or nil when it is not encrypted
+func tlsSummary(state *tls.ConnectionState) *types.TLS {
+	if state == nil {
+		return nil
+	}
+
+	summary := &types.TLS{
+		Version:     tls.VersionName(state.Version),
+		CipherSuite: tls.CipherSuiteName(state.CipherSuite),
+		ServerName:  state.ServerName,
+	}
+
+	if len(state.PeerCertificates) > 0 {
+		leaf := state.PeerCertificates[0]
+		summary.PeerCertificate = types.Certificate{
+			Subject:   leaf.Subject.String(),
+			Issuer:    leaf.Issuer.String(),
+			DNSNames:  leaf.DNSNames,
+			NotBefore: leaf.NotBefore,
+			NotAfter:  leaf.NotAfter,
+		}
+	}
+
+	return summary
+}
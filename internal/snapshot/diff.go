@@ -0,0 +1,222 @@
+package snapshot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around the changes
+const diffContext = 3
+
+// edit defines a line of the diff: ' ' when it is in both texts, '-' when it is only in the first
+// one and '+' when it is only in the second one
+type edit struct {
+	op   byte
+	line string
+}
+
+// unifiedDiff returns the changes between the texts in the unified format, empty when they are equal
+func unifiedDiff(fromName, toName, from, to string) string {
+	edits := diffLines(splitLines(from), splitLines(to))
+	changed := false
+	for _, e := range edits {
+		changed = changed || e.op != ' '
+	}
+	if !changed {
+		return ""
+	}
+
+	result := strings.Builder{}
+	result.WriteString("--- " + fromName + "\n")
+	result.WriteString("+++ " + toName + "\n")
+
+	// fromLine and toLine are the lines of each text before the edit at the same index
+	fromLine := make([]int, len(edits)+1)
+	toLine := make([]int, len(edits)+1)
+	for i, e := range edits {
+		fromLine[i+1], toLine[i+1] = fromLine[i], toLine[i]
+		if e.op != '+' {
+			fromLine[i+1]++
+		}
+		if e.op != '-' {
+			toLine[i+1]++
+		}
+	}
+
+	for start := 0; start < len(edits); {
+		first := start
+		for first < len(edits) && edits[first].op == ' ' {
+			first++
+		}
+		if first == len(edits) {
+			break
+		}
+
+		// the hunk continues while the next change is close enough to share the context
+		last := first
+		for i := first; i < len(edits) && i <= last+2*diffContext; i++ {
+			if edits[i].op != ' ' {
+				last = i
+			}
+		}
+
+		begin := max(first-diffContext, 0)
+		end := min(last+diffContext+1, len(edits))
+		fromCount, toCount := fromLine[end]-fromLine[begin], toLine[end]-toLine[begin]
+		fromStart, toStart := fromLine[begin], toLine[begin]
+		if fromCount > 0 {
+			fromStart++
+		}
+		if toCount > 0 {
+			toStart++
+		}
+
+		result.WriteString(fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", fromStart, fromCount, toStart, toCount))
+		for _, e := range edits[begin:end] {
+			result.WriteString(string(e.op) + e.line + "\n")
+		}
+
+		start = end
+	}
+
+	return result.String()
+}
+
+// diffLines returns the shortest edits turning a into b, with the linear space algorithm of Myers.
+// The removed lines of each change are placed before the added ones.
+func diffLines(a, b []string) []edit {
+	edits := appendEdits(make([]edit, 0, len(a)+len(b)), a, b)
+
+	for start := 0; start < len(edits); start++ {
+		end := start
+		for end < len(edits) && edits[end].op != ' ' {
+			end++
+		}
+		sort.SliceStable(edits[start:end], func(i, j int) bool {
+			return edits[start+i].op == '-' && edits[start+j].op == '+'
+		})
+		start = end
+	}
+
+	return edits
+}
+
+// appendEdits appends the edits turning a into b, keeping the common lines at the start and the end
+// out of the search and splitting the rest at the middle of the shortest edits
+func appendEdits(edits []edit, a, b []string) []edit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		edits = append(edits, edit{op: ' ', line: a[prefix]})
+		prefix++
+	}
+	a, b = a[prefix:], b[prefix:]
+
+	suffix := 0
+	for suffix < len(a) && suffix < len(b) && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	common := a[len(a)-suffix:]
+	a, b = a[:len(a)-suffix], b[:len(b)-suffix]
+
+	switch {
+	case len(a) == 0 || len(b) == 0 || len(a)+len(b) == 2:
+		for _, line := range a {
+			edits = append(edits, edit{op: '-', line: line})
+		}
+		for _, line := range b {
+			edits = append(edits, edit{op: '+', line: line})
+		}
+	default:
+		x, y := middle(a, b)
+		edits = appendEdits(edits, a[:x], b[:y])
+		edits = appendEdits(edits, a[x:], b[y:])
+	}
+
+	for _, line := range common {
+		edits = append(edits, edit{op: ' ', line: line})
+	}
+
+	return edits
+}
+
+// middle returns the point where the shortest edits turning a into b are split in two, searching
+// them from the start and from the end at the same time until both searches overlap. The texts
+// have no common first or last line, and at least three lines between both.
+func middle(a, b []string) (int, int) {
+	n, m := len(a), len(b)
+	maxD := (n + m + 1) / 2
+	offset := maxD
+	forward := make([]int, 2*maxD)
+	backward := make([]int, 2*maxD)
+	for i := range forward {
+		forward[i], backward[i] = -1, -1
+	}
+	forward[offset+1], backward[offset+1] = 0, 0
+
+	// the forward search checks the overlap when the difference of lengths is odd, the backward one otherwise
+	delta := n - m
+	odd := delta%2 != 0
+	// the diagonals that went past the end of a text are skipped
+	forwardStart, forwardEnd, backwardStart, backwardEnd := 0, 0, 0, 0
+
+	for d := 0; d < maxD; d++ {
+		for k := -d + forwardStart; k <= d-forwardEnd; k += 2 {
+			x := forward[offset+k-1] + 1
+			if k == -d || (k != d && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1]
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			forward[offset+k] = x
+
+			if x > n {
+				forwardEnd += 2
+			} else if y > m {
+				forwardStart += 2
+			} else if odd {
+				if i := offset + delta - k; i >= 0 && i < len(backward) && backward[i] != -1 && x >= n-backward[i] {
+					return x, y
+				}
+			}
+		}
+
+		for k := -d + backwardStart; k <= d-backwardEnd; k += 2 {
+			x := backward[offset+k-1] + 1
+			if k == -d || (k != d && backward[offset+k-1] < backward[offset+k+1]) {
+				x = backward[offset+k+1]
+			}
+			y := x - k
+			for x < n && y < m && a[n-x-1] == b[m-y-1] {
+				x++
+				y++
+			}
+			backward[offset+k] = x
+
+			if x > n {
+				backwardEnd += 2
+			} else if y > m {
+				backwardStart += 2
+			} else if !odd {
+				if i := offset + delta - k; i >= 0 && i < len(forward) && forward[i] != -1 && forward[i] >= n-x {
+					return forward[i], forward[i] - (delta - k)
+				}
+			}
+		}
+	}
+
+	// the texts have no line in common, every line of a is removed before adding the ones of b
+	return n, 0
+}
+
+// splitLines returns the lines of the text without the line break of the last one
+func splitLines(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
@@ -0,0 +1,39 @@
+package snapshot
+
+type CanNotReadSnapshotError struct {
+	path string
+	err  error
+}
+
+type CanNotWriteSnapshotError struct {
+	path string
+	err  error
+}
+
+type SnapshotNotFoundError struct {
+	path string
+}
+
+func NewSnapshotNotFoundError(path string) error {
+	return SnapshotNotFoundError{path}
+}
+
+func NewCanNotReadSnapshotError(path string, err error) error {
+	return CanNotReadSnapshotError{path, err}
+}
+
+func NewCanNotWriteSnapshotError(path string, err error) error {
+	return CanNotWriteSnapshotError{path, err}
+}
+
+func (e CanNotReadSnapshotError) Error() string {
+	return "can not read snapshot " + e.path + ": " + e.err.Error()
+}
+
+func (e CanNotWriteSnapshotError) Error() string {
+	return "can not write snapshot " + e.path + ": " + e.err.Error()
+}
+
+func (e SnapshotNotFoundError) Error() string {
+	return "snapshot " + e.path + " not found, run with --update-snapshots"
+}
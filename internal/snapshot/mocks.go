@@ -0,0 +1,16 @@
+package snapshot
+
+import "github.com/jibaru/do/internal/types"
+
+type Mock struct {
+	UpdateFn  func(filename string, config *types.SnapshotConfig, response types.Response) (*types.SnapshotResult, error)
+	CompareFn func(filename string, config *types.SnapshotConfig, response types.Response) (*types.SnapshotResult, error)
+}
+
+func (m *Mock) Update(filename string, config *types.SnapshotConfig, response types.Response) (*types.SnapshotResult, error) {
+	return m.UpdateFn(filename, config, response)
+}
+
+func (m *Mock) Compare(filename string, config *types.SnapshotConfig, response types.Response) (*types.SnapshotResult, error) {
+	return m.CompareFn(filename, config, response)
+}
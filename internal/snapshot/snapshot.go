@@ -0,0 +1,131 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jibaru/do/internal/jsonpath"
+	"github.com/jibaru/do/internal/types"
+)
+
+const (
+	// Extension is the extension of the golden files, written next to the do files
+	Extension = ".snap.json"
+	// Ignored replaces the values of the ignored json paths
+	Ignored = "<ignored>"
+)
+
+type Snapshotter interface {
+	// Update writes the snapshot of the response to the golden file of the do file.
+	Update(filename string, config *types.SnapshotConfig, response types.Response) (*types.SnapshotResult, error)
+	// Compare diffs the golden file of the do file against the snapshot of the response.
+	// It returns nil when the golden file does not exist and the do file has no snapshot section,
+	// and an error when it has one, so a golden file that was not recorded is not skipped.
+	Compare(filename string, config *types.SnapshotConfig, response types.Response) (*types.SnapshotResult, error)
+}
+
+type snapshotter struct{}
+
+func New() Snapshotter {
+	return &snapshotter{}
+}
+
+// snapshot defines the normalized response kept in a golden file
+type snapshot struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    interface{}       `json:"body"`
+}
+
+// Path returns the golden file of the do file, replacing its extension
+func Path(filename string) string {
+	return strings.TrimSuffix(filename, filepath.Ext(filename)) + Extension
+}
+
+func (s *snapshotter) Update(filename string, config *types.SnapshotConfig, response types.Response) (*types.SnapshotResult, error) {
+	path := Path(filename)
+
+	content, err := normalize(config, response)
+	if err != nil {
+		return nil, NewCanNotWriteSnapshotError(path, err)
+	}
+
+	if err = os.WriteFile(path, content, 0644); err != nil {
+		return nil, NewCanNotWriteSnapshotError(path, err)
+	}
+
+	return &types.SnapshotResult{Path: path, Updated: true, Passed: true}, nil
+}
+
+func (s *snapshotter) Compare(filename string, config *types.SnapshotConfig, response types.Response) (*types.SnapshotResult, error) {
+	path := Path(filename)
+
+	recorded, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		if config != nil {
+			return nil, NewSnapshotNotFoundError(path)
+		}
+		return nil, nil
+	} else if err != nil {
+		return nil, NewCanNotReadSnapshotError(path, err)
+	}
+
+	content, err := normalize(config, response)
+	if err != nil {
+		return nil, NewCanNotReadSnapshotError(path, err)
+	}
+
+	diff := unifiedDiff(path, path+" (response)", string(recorded), string(content))
+
+	return &types.SnapshotResult{Path: path, Passed: diff == "", Diff: diff}, nil
+}
+
+// normalize returns the snapshot of the response as indented json. A json body is kept as json with
+// the values of the ignored paths replaced, any other body is kept as a string.
+func normalize(config *types.SnapshotConfig, response types.Response) ([]byte, error) {
+	if config == nil {
+		config = &types.SnapshotConfig{}
+	}
+
+	result := snapshot{Status: response.StatusCode, Body: response.Body}
+
+	for _, name := range config.Headers {
+		for key, value := range response.Headers {
+			if values, ok := value.([]string); ok && strings.EqualFold(key, name) {
+				if result.Headers == nil {
+					result.Headers = make(map[string]string)
+				}
+				result.Headers[name] = strings.Join(values, ", ")
+			}
+		}
+	}
+
+	if document, err := jsonpath.Decode([]byte(response.Body)); err == nil {
+		for _, raw := range config.Ignore {
+			path, err := jsonpath.Parse(raw)
+			if err != nil {
+				return nil, err
+			}
+
+			// a path that is not in the body has nothing to ignore
+			if replaced, err := path.Replace(document, Ignored); err == nil {
+				document = replaced
+			}
+		}
+		result.Body = document
+	}
+
+	content := bytes.Buffer{}
+	encoder := json.NewEncoder(&content)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return nil, err
+	}
+
+	return content.Bytes(), nil
+}
@@ -0,0 +1,240 @@
+package snapshot_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jibaru/do/internal/snapshot"
+	"github.com/jibaru/do/internal/types"
+)
+
+func TestPath(t *testing.T) {
+	if path := snapshot.Path("api/users.do"); path != "api/users.snap.json" {
+		t.Errorf("expected api/users.snap.json, got %s", path)
+	}
+}
+
+func TestSnapshotter_Update(t *testing.T) {
+	config := &types.SnapshotConfig{
+		Headers: []string{"Content-Type", "X-Missing"},
+		Ignore:  []string{"$.createdAt", "$.items[*].id", "$.missing"},
+	}
+	response := types.Response{
+		StatusCode: 200,
+		Headers:    map[string]interface{}{"content-type": []string{"application/json"}, "Date": []string{"today"}},
+		Body:       `{"createdAt": "2024-05-01", "name": "<john>", "total": 10.50, "items": [{"id": 1, "sku": "a"}, {"id": 2, "sku": "b"}]}`,
+	}
+
+	testCases := []struct {
+		name          string
+		config        *types.SnapshotConfig
+		response      types.Response
+		expected      string
+		expectedError error
+	}{
+		{
+			name:     "success json body",
+			config:   config,
+			response: response,
+			expected: `{
+  "status": 200,
+  "headers": {
+    "Content-Type": "application/json"
+  },
+  "body": {
+    "createdAt": "<ignored>",
+    "items": [
+      {
+        "id": "<ignored>",
+        "sku": "a"
+      },
+      {
+        "id": "<ignored>",
+        "sku": "b"
+      }
+    ],
+    "name": "<john>",
+    "total": 10.50
+  }
+}
+`,
+		},
+		{
+			name:     "success text body without config",
+			response: types.Response{StatusCode: 404, Body: "not found"},
+			expected: `{
+  "status": 404,
+  "body": "not found"
+}
+`,
+		},
+	}
+
+	s := snapshot.New()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "users.do")
+
+			result, err := s.Update(filename, tc.config, tc.response)
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			expectedResult := &types.SnapshotResult{Path: snapshot.Path(filename), Updated: true, Passed: true}
+			if !reflect.DeepEqual(result, expectedResult) {
+				t.Errorf("expected %#v, got %#v", expectedResult, result)
+			}
+
+			content, _ := os.ReadFile(snapshot.Path(filename))
+			if string(content) != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, string(content))
+			}
+		})
+	}
+}
+
+func TestSnapshotter_Compare(t *testing.T) {
+	recorded := `{
+  "status": 200,
+  "body": {
+    "a": 1,
+    "b": 2,
+    "c": 3,
+    "d": 4,
+    "e": 5,
+    "f": 6,
+    "g": 7,
+    "h": 8,
+    "i": 9,
+    "j": 10
+  }
+}
+`
+
+	testCases := []struct {
+		name          string
+		recorded      string
+		config        *types.SnapshotConfig
+		response      types.Response
+		expected      *types.SnapshotResult
+		expectedError error
+	}{
+		{
+			name:     "success equal",
+			recorded: recorded,
+			response: types.Response{StatusCode: 200, Body: `{"j":10,"i":9,"h":8,"g":7,"f":6,"e":5,"d":4,"c":3,"b":2,"a":1}`},
+			expected: &types.SnapshotResult{Passed: true},
+		},
+		{
+			name:     "success changed",
+			recorded: recorded,
+			response: types.Response{StatusCode: 201, Body: `{"a":1,"b":2,"c":3,"d":4,"e":5,"f":6,"g":7,"h":8,"i":9,"j":11,"k":12}`},
+			expected: &types.SnapshotResult{Diff: `--- {path}
++++ {path} (response)
+@@ -1,5 +1,5 @@
+ {
+-  "status": 200,
++  "status": 201,
+   "body": {
+     "a": 1,
+     "b": 2,
+@@ -10,6 +10,7 @@
+     "g": 7,
+     "h": 8,
+     "i": 9,
+-    "j": 10
++    "j": 11,
++    "k": 12
+   }
+ }
+`},
+		},
+		{
+			name:     "success without golden file",
+			response: types.Response{StatusCode: 200},
+		},
+		{
+			name:          "error without golden file for a snapshot section",
+			config:        &types.SnapshotConfig{},
+			response:      types.Response{StatusCode: 200},
+			expectedError: errors.New("snapshot {path} not found, run with --update-snapshots"),
+		},
+	}
+
+	s := snapshot.New()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "users.do")
+			path := snapshot.Path(filename)
+			if tc.recorded != "" {
+				if err := os.WriteFile(path, []byte(tc.recorded), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			result, err := s.Compare(filename, tc.config, tc.response)
+
+			if tc.expectedError != nil {
+				tc.expectedError = errors.New(strings.ReplaceAll(tc.expectedError.Error(), "{path}", path))
+			}
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			} else if err != nil && tc.expectedError != nil && err.Error() != tc.expectedError.Error() {
+				t.Errorf("expected error %v, got %v", tc.expectedError, err)
+			}
+
+			if tc.expected != nil {
+				tc.expected.Path = path
+				tc.expected.Diff = strings.ReplaceAll(tc.expected.Diff, "{path}", path)
+			}
+
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Errorf("expected %#v, got %#v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestSnapshotter_Compare_LargeBody(t *testing.T) {
+	const lines = 5000
+
+	recorded, changed := make([]string, lines), make([]string, lines)
+	for i := range recorded {
+		recorded[i] = strconv.Itoa(i)
+		changed[i] = strconv.Itoa(lines + i)
+	}
+
+	s := snapshot.New()
+	filename := filepath.Join(t.TempDir(), "users.do")
+
+	if _, err := s.Update(filename, nil, types.Response{StatusCode: 200, Body: "[" + strings.Join(recorded, ",") + "]"}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := s.Compare(filename, nil, types.Response{StatusCode: 200, Body: "[" + strings.Join(changed, ",") + "]"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if result.Passed {
+		t.Errorf("expected the snapshot to not pass")
+	}
+	if removed, added := strings.Count(result.Diff, "\n-    "), strings.Count(result.Diff, "\n+    "); removed != lines || added != lines {
+		t.Errorf("expected %d removed and added lines, got %d and %d", lines, removed, added)
+	}
+}
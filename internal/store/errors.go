@@ -0,0 +1,26 @@
+package store
+
+type InvalidStoreError struct {
+	path string
+	err  error
+}
+
+type InvalidNameError struct {
+	name string
+}
+
+func NewInvalidStoreError(path string, err error) error {
+	return InvalidStoreError{path, err}
+}
+
+func NewInvalidNameError(name string) error {
+	return InvalidNameError{name}
+}
+
+func (e InvalidStoreError) Error() string {
+	return "invalid store " + e.path + ": " + e.err.Error()
+}
+
+func (e InvalidNameError) Error() string {
+	return "invalid store name " + e.name + ": use letters, digits and '_'"
+}
@@ -0,0 +1,36 @@
+package store
+
+import "time"
+
+type Mock struct {
+	GetFn    func(name string) (interface{}, bool, error)
+	SetFn    func(name string, value interface{}, ttl time.Duration) error
+	DeleteFn func(name string) (bool, error)
+	AllFn    func() ([]Entry, error)
+	ClearFn  func()
+	SaveFn   func() error
+}
+
+func (m *Mock) Get(name string) (interface{}, bool, error) {
+	return m.GetFn(name)
+}
+
+func (m *Mock) Set(name string, value interface{}, ttl time.Duration) error {
+	return m.SetFn(name, value, ttl)
+}
+
+func (m *Mock) Delete(name string) (bool, error) {
+	return m.DeleteFn(name)
+}
+
+func (m *Mock) All() ([]Entry, error) {
+	return m.AllFn()
+}
+
+func (m *Mock) Clear() {
+	m.ClearFn()
+}
+
+func (m *Mock) Save() error {
+	return m.SaveFn()
+}
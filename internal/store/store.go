@@ -0,0 +1,224 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jibaru/do/internal/types"
+)
+
+// Path is the file, relative to the working directory, where the values are kept between runs
+const Path = ".do/store.json"
+
+// Entry defines a value kept in the store
+type Entry struct {
+	Name      string      `json:"-"`
+	Value     interface{} `json:"value"`
+	ExpiresAt *time.Time  `json:"expires_at,omitempty"`
+}
+
+type Store interface {
+	types.StoreReader
+	// Set keeps the value with the name, it expires after the ttl when it is greater than zero.
+	Set(name string, value interface{}, ttl time.Duration) error
+	// Delete removes the value with the name and returns true when it was found.
+	Delete(name string) (bool, error)
+	// All returns the entries that are not expired, sorted by name.
+	All() ([]Entry, error)
+	// Clear removes every value.
+	Clear()
+	// Save writes the values to the file of the store, creating its directory.
+	Save() error
+}
+
+type store struct {
+	mu      sync.Mutex
+	path    string
+	entries map[string]Entry
+	loaded  bool
+	now     func() time.Time
+}
+
+// New returns a store persisted in the file at path. The file is read the first time a value is needed.
+func New(path string) Store {
+	return &store{path: path, entries: make(map[string]Entry), now: time.Now}
+}
+
+func (s *store) Get(name string) (interface{}, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return nil, false, err
+	}
+
+	entry, ok := s.entries[name]
+	if !ok || s.expired(entry) {
+		return nil, false, nil
+	}
+
+	return entry.Value, true, nil
+}
+
+func (s *store) Set(name string, value interface{}, ttl time.Duration) error {
+	if !isValidName(name) {
+		return NewInvalidNameError(name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return err
+	}
+
+	entry := Entry{Name: name, Value: value}
+	if ttl > 0 {
+		expiresAt := s.now().Add(ttl).UTC()
+		entry.ExpiresAt = &expiresAt
+	}
+	s.entries[name] = entry
+
+	return nil
+}
+
+func (s *store) Delete(name string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return false, err
+	}
+
+	entry, ok := s.entries[name]
+	delete(s.entries, name)
+
+	return ok && !s.expired(entry), nil
+}
+
+func (s *store) All() ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(s.entries))
+	for _, entry := range s.entries {
+		if !s.expired(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+
+	return entries, nil
+}
+
+func (s *store) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the file is replaced on save, it does not need to be read
+	s.entries = make(map[string]Entry)
+	s.loaded = true
+}
+
+func (s *store) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.load(); err != nil {
+		return err
+	}
+
+	entries := make(map[string]Entry, len(s.entries))
+	for name, entry := range s.entries {
+		if !s.expired(entry) {
+			entries[name] = entry
+		}
+	}
+
+	content, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return NewInvalidStoreError(s.path, err)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return NewInvalidStoreError(s.path, err)
+	}
+
+	// the values may be tokens, only the owner can read them
+	if err = os.WriteFile(s.path, append(content, '\n'), 0600); err != nil {
+		return NewInvalidStoreError(s.path, err)
+	}
+
+	return nil
+}
+
+// load reads the file of the store once, a missing file is an empty store
+func (s *store) load() error {
+	if s.loaded {
+		return nil
+	}
+
+	content, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		s.loaded = true
+		return nil
+	} else if err != nil {
+		return NewInvalidStoreError(s.path, err)
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(content)))
+	decoder.UseNumber()
+
+	var entries map[string]Entry
+	if err = decoder.Decode(&entries); err != nil {
+		return NewInvalidStoreError(s.path, err)
+	}
+
+	for name, entry := range entries {
+		entry.Name = name
+		entry.Value = toVariable(entry.Value)
+		s.entries[name] = entry
+	}
+	s.loaded = true
+
+	return nil
+}
+
+func (s *store) expired(entry Entry) bool {
+	return entry.ExpiresAt != nil && !entry.ExpiresAt.After(s.now())
+}
+
+// toVariable converts a json value to the type of a variable
+func toVariable(value interface{}) interface{} {
+	switch val := value.(type) {
+	case string:
+		return types.String(val)
+	case bool:
+		return types.Bool(val)
+	case json.Number:
+		if integer, err := val.Int64(); err == nil {
+			return types.Int(integer)
+		}
+		float, _ := val.Float64()
+		return types.Float(float)
+	}
+
+	return types.String("")
+}
+
+// isValidName returns true when the name can be used as a variable
+func isValidName(name string) bool {
+	return name != "" && strings.Trim(name, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_") == ""
+}
@@ -0,0 +1,163 @@
+package store_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jibaru/do/internal/store"
+	"github.com/jibaru/do/internal/types"
+)
+
+func TestStore_Get(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+	content := `{
+  "token": {"value": "abc"},
+  "id": {"value": 12},
+  "price": {"value": 9.5},
+  "admin": {"value": true},
+  "old": {"value": "expired", "expires_at": "2020-01-01T00:00:00Z"},
+  "later": {"value": "valid", "expires_at": "2999-01-01T00:00:00Z"}
+}`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name          string
+		path          string
+		key           string
+		expected      interface{}
+		expectedFound bool
+		expectedError error
+	}{
+		{
+			name:          "success string",
+			path:          path,
+			key:           "token",
+			expected:      types.String("abc"),
+			expectedFound: true,
+		},
+		{
+			name:          "success int",
+			path:          path,
+			key:           "id",
+			expected:      types.Int(12),
+			expectedFound: true,
+		},
+		{
+			name:          "success float",
+			path:          path,
+			key:           "price",
+			expected:      types.Float(9.5),
+			expectedFound: true,
+		},
+		{
+			name:          "success bool",
+			path:          path,
+			key:           "admin",
+			expected:      types.Bool(true),
+			expectedFound: true,
+		},
+		{
+			name:          "success not expired",
+			path:          path,
+			key:           "later",
+			expected:      types.String("valid"),
+			expectedFound: true,
+		},
+		{
+			name: "success expired",
+			path: path,
+			key:  "old",
+		},
+		{
+			name: "success missing file",
+			path: filepath.Join(t.TempDir(), "missing.json"),
+			key:  "token",
+		},
+		{
+			name:          "error invalid file",
+			path:          t.TempDir(),
+			key:           "token",
+			expectedError: errors.New("invalid store"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, found, err := store.New(tc.path).Get(tc.key)
+
+			if err != nil && tc.expectedError == nil {
+				t.Errorf("expected no error, got %v", err)
+			} else if err == nil && tc.expectedError != nil {
+				t.Errorf("expected error %v, got no error", tc.expectedError)
+			}
+
+			if value != tc.expected || found != tc.expectedFound {
+				t.Errorf("expected %v %v, got %v %v", tc.expected, tc.expectedFound, value, found)
+			}
+		})
+	}
+}
+
+func TestStore_Save(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".do", "store.json")
+
+	s := store.New(path)
+	if err := s.Set("token", types.String("abc"), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("id", types.Int(12), 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("removed", types.Bool(true), 0); err != nil {
+		t.Fatal(err)
+	}
+	if found, err := s.Delete("removed"); !found || err != nil {
+		t.Fatalf("expected the value to be deleted, got %v %v", found, err)
+	}
+	if err := s.Set("invalid name", types.String("x"), 0); err == nil || err.Error() != "invalid store name invalid name: use letters, digits and '_'" {
+		t.Errorf("expected invalid name error, got %v", err)
+	}
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected the store to be private, got %v", info.Mode().Perm())
+	}
+
+	entries, err := store.New(path).All()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names, values := make([]string, 0), make([]interface{}, 0)
+	for _, entry := range entries {
+		names, values = append(names, entry.Name), append(values, entry.Value)
+	}
+	if !reflect.DeepEqual(names, []string{"id", "token"}) || !reflect.DeepEqual(values, []interface{}{types.Int(12), types.String("abc")}) {
+		t.Errorf("expected id=12 and token=abc, got %v %v", names, values)
+	}
+
+	if entries[0].ExpiresAt != nil || entries[1].ExpiresAt == nil || entries[1].ExpiresAt.Before(time.Now().Add(59*time.Minute)) {
+		t.Errorf("expected only token to expire in an hour, got %v %v", entries[0].ExpiresAt, entries[1].ExpiresAt)
+	}
+
+	cleared := store.New(path)
+	cleared.Clear()
+	if err = cleared.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ = store.New(path).All(); len(entries) != 0 {
+		t.Errorf("expected no entries after clear, got %v", entries)
+	}
+}
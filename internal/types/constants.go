@@ -1,8 +1,12 @@
 package types
 
 const (
-	LetSection Section = "let"
-	DoSection  Section = "do"
+	LetSection      Section = "let"
+	DoSection       Section = "do"
+	OptionsSection  Section = "options"
+	CaptureSection  Section = "capture"
+	ExpectSection   Section = "expect"
+	SnapshotSection Section = "snapshot"
 )
 
 const (
@@ -12,4 +16,69 @@ const (
 	DoQuery   = "query"
 	DoHeaders = "headers"
 	DoBody    = "body"
+	DoAuth    = "auth"
+)
+
+const (
+	AuthBasic  = "basic"
+	AuthBearer = "bearer"
+	AuthDigest = "digest"
+	AuthOAuth2 = "oauth2"
+	AuthSigV4  = "sigv4"
+)
+
+const (
+	CaptureJSON   = "json"
+	CaptureHeader = "header"
+	CaptureCookie = "cookie"
+	CaptureRegex  = "regex"
+	CaptureStatus = "status"
+	CaptureBody   = "body"
+)
+
+const (
+	ExpectStatus   = "status"
+	ExpectHeaders  = "headers"
+	ExpectJSON     = "json"
+	ExpectContains = "contains"
+	ExpectMatches  = "matches"
+	ExpectMaxTime  = "max_time"
+	ExpectMaxSize  = "max_size"
+)
+
+const (
+	SnapshotHeaders = "headers"
+	SnapshotIgnore  = "ignore"
+)
+
+const (
+	GrantClientCredentials = "client_credentials"
+	GrantPassword          = "password"
+	GrantRefreshToken      = "refresh_token"
+)
+
+const (
+	OptionTimeout             = "timeout"
+	OptionConnectTimeout      = "connect_timeout"
+	OptionTLSTimeout          = "tls_timeout"
+	OptionResponseTimeout     = "response_timeout"
+	OptionRetries             = "retries"
+	OptionRetryOn             = "retry_on"
+	OptionRetryOnNetworkError = "retry_on_network_error"
+	OptionBackoff             = "backoff"
+	OptionMaxBackoff          = "max_backoff"
+	OptionFollowRedirects     = "follow_redirects"
+	OptionMaxRedirects        = "max_redirects"
+	OptionKeepAuthorization   = "keep_authorization"
+	OptionCACert              = "ca_cert"
+	OptionClientCert          = "client_cert"
+	OptionClientKey           = "client_key"
+	OptionTLSMinVersion       = "tls_min_version"
+	OptionTLSServerName       = "tls_server_name"
+	OptionInsecure            = "insecure"
+	OptionProxy               = "proxy"
+	OptionNoProxy             = "no_proxy"
+	OptionCookieJar           = "cookie_jar"
+	OptionSession             = "session"
+	OptionStoreTTL            = "store_ttl"
 )
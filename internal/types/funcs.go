@@ -20,12 +20,39 @@ func (e EmptyArgError) Error() string {
 }
 
 const (
-	FileFuncName = "file"
-	EnvFuncName  = "env"
-	UuidFuncName = "uuid"
-	DateFuncName = "date"
+	FileFuncName  = "file"
+	EnvFuncName   = "env"
+	UuidFuncName  = "uuid"
+	DateFuncName  = "date"
+	StoreFuncName = "store"
 )
 
+// FuncNames defines the names of all the available functions
+var FuncNames = []string{
+	EnvFuncName,
+	FileFuncName,
+	UuidFuncName,
+	DateFuncName,
+	StoreFuncName,
+}
+
+// IsFuncName returns true if name is an available function
+func IsFuncName(name string) bool {
+	for _, funcName := range FuncNames {
+		if funcName == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StoreReader defines the source of the values read by the store function
+type StoreReader interface {
+	// Get returns the value kept with the name when it is not expired
+	Get(name string) (interface{}, bool, error)
+}
+
 type DateGenerator interface {
 	Now() time.Time
 }
@@ -64,6 +91,7 @@ func (f Func) HasReferences() bool {
 func (f Func) Resolve(
 	uuidFactory utils.UuidFactory,
 	dateFactory utils.DateFactory,
+	storeReader StoreReader,
 ) (interface{}, error) {
 	switch f.Name {
 	case FileFuncName:
@@ -74,6 +102,8 @@ func (f Func) Resolve(
 		return NewUuidFuncFromArgs(f.Args, uuidFactory)
 	case DateFuncName:
 		return NewDateFuncFromArgs(f.Args, dateFactory)
+	case StoreFuncName:
+		return NewStoreFuncFromArgs(f.Args, storeReader)
 	}
 
 	return nil, errors.New("unknown function")
@@ -205,3 +235,50 @@ func NewDateFunc(formatName String, dateFactory utils.DateFactory) (DateFunc, er
 func (f DateFunc) Exec() String {
 	return String(f.DateFactory.Now().UTC().Format(string(f.Format)))
 }
+
+type StoreFunc struct {
+	Name        String
+	Default     String
+	StoreReader StoreReader
+}
+
+func NewStoreFuncFromArgs(args []interface{}, storeReader StoreReader) (StoreFunc, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return StoreFunc{}, errors.New("invalid number of arguments for store")
+	}
+
+	name, ok := args[0].(String)
+	if !ok {
+		return StoreFunc{}, errors.New("invalid argument type for store")
+	}
+
+	var defaultValue String
+	if len(args) == 2 {
+		if defaultValue, ok = args[1].(String); !ok {
+			return StoreFunc{}, errors.New("invalid argument type for store")
+		}
+	}
+
+	if strings.TrimSpace(string(name)) == "" {
+		return StoreFunc{}, EmptyArgError{funcName: StoreFuncName, position: 1}
+	}
+
+	return StoreFunc{Name: name, Default: defaultValue, StoreReader: storeReader}, nil
+}
+
+// Exec returns the value kept in the store, or the default when it is not found or expired
+func (f StoreFunc) Exec() (interface{}, error) {
+	if f.StoreReader == nil {
+		return f.Default, nil
+	}
+
+	value, ok, err := f.StoreReader.Get(string(f.Name))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return f.Default, nil
+	}
+
+	return value, nil
+}
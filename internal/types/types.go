@@ -2,6 +2,10 @@ package types
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
 )
 
 // Section defines the type of section
@@ -25,6 +29,44 @@ type SectionExpressions []string
 // Let defines the variables section
 type Let struct {
 	Variables map[string]interface{} `json:"variables"`
+	// Secrets are the names of the variables read from the store, masked in the output
+	Secrets []string `json:"-"`
+}
+
+// MarshalJSON returns the variables with the values of the secrets masked
+func (l Let) MarshalJSON() ([]byte, error) {
+	variables := l.Variables
+	if len(l.Secrets) > 0 && variables != nil {
+		variables = make(map[string]interface{}, len(l.Variables))
+		for key, value := range l.Variables {
+			variables[key] = value
+		}
+		for _, name := range l.Secrets {
+			if value, ok := variables[name]; ok {
+				variables[name] = Mask(fmt.Sprintf("%v", value))
+			}
+		}
+	}
+
+	return json.Marshal(struct {
+		Variables map[string]interface{} `json:"variables"`
+	}{variables})
+}
+
+// SecretValues returns the values of the secrets that are strings, the ones that are masked in the
+// rest of the output
+func (l Let) SecretValues() []string {
+	values := make([]string, 0, len(l.Secrets))
+	for _, name := range l.Secrets {
+		switch value := l.Variables[name].(type) {
+		case string:
+			values = append(values, value)
+		case String:
+			values = append(values, string(value))
+		}
+	}
+
+	return values
 }
 
 // Do defines the request section
@@ -35,12 +77,253 @@ type Do struct {
 	Query   Map         `json:"query"`
 	Headers Map         `json:"headers"`
 	Body    interface{} `json:"body"`
+	Auth    *Auth       `json:"auth"`
+}
+
+// Auth defines the credentials used to authenticate the request, the OAuth2 fields
+// are only used to get the token of oauth2
+type Auth struct {
+	Type         string `json:"type"`
+	Username     string `json:"username"`
+	Password     string `json:"password"`
+	Token        string `json:"token"`
+	GrantType    string `json:"grant_type"`
+	TokenURL     string `json:"token_url"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	Scope        string `json:"scope"`
+	RefreshToken string `json:"refresh_token"`
+	AccessKey    string `json:"access_key"`
+	SecretKey    string `json:"secret_key"`
+	SessionToken string `json:"session_token"`
+	Region       string `json:"region"`
+	Service      string `json:"service"`
+}
+
+// MarshalJSON returns the auth with the password, the secrets and the tokens masked
+func (a Auth) MarshalJSON() ([]byte, error) {
+	type auth Auth
+	masked := auth(a)
+	masked.Password = Mask(masked.Password)
+	masked.Token = Mask(masked.Token)
+	masked.ClientSecret = Mask(masked.ClientSecret)
+	masked.RefreshToken = Mask(masked.RefreshToken)
+	masked.SecretKey = Mask(masked.SecretKey)
+	masked.SessionToken = Mask(masked.SessionToken)
+
+	return json.Marshal(masked)
+}
+
+// Mask hides a secret value, keeping it empty when it is not defined
+func Mask(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	return "****"
+}
+
+// MaskText replaces the secrets found in the text, as they are or query escaped, with Mask
+func MaskText(text string, secrets []string) string {
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+		text = strings.ReplaceAll(text, secret, Mask(secret))
+		if escaped := url.QueryEscape(secret); escaped != secret {
+			text = strings.ReplaceAll(text, escaped, Mask(secret))
+		}
+	}
+
+	return text
+}
+
+// MaskSecrets returns a copy of the value with the secrets found in its strings masked,
+// walking its maps and slices. Other values are returned as they are.
+func MaskSecrets(value interface{}, secrets []string) interface{} {
+	if len(secrets) == 0 {
+		return value
+	}
+
+	switch v := value.(type) {
+	case string:
+		return MaskText(v, secrets)
+	case String:
+		return String(MaskText(string(v), secrets))
+	case Map:
+		if v == nil {
+			return v
+		}
+		masked := make(Map, len(v))
+		for key, item := range v {
+			masked[key] = MaskSecrets(item, secrets)
+		}
+		return masked
+	case map[string]interface{}:
+		if v == nil {
+			return v
+		}
+		masked := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			masked[key] = MaskSecrets(item, secrets)
+		}
+		return masked
+	case []string:
+		masked := make([]string, len(v))
+		for i, item := range v {
+			masked[i] = MaskText(item, secrets)
+		}
+		return masked
+	case []interface{}:
+		masked := make([]interface{}, len(v))
+		for i, item := range v {
+			masked[i] = MaskSecrets(item, secrets)
+		}
+		return masked
+	}
+
+	return value
+}
+
+// Duration defines a duration written as "300ms", "1.5s" or "2m"
+type Duration time.Duration
+
+// MarshalJSON returns the duration as a string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// Options defines how the request is sent, a zero value means no limit or no retries,
+// and following up to 10 redirects
+type Options struct {
+	Timeout             Duration `json:"timeout"`
+	ConnectTimeout      Duration `json:"connect_timeout"`
+	TLSTimeout          Duration `json:"tls_timeout"`
+	ResponseTimeout     Duration `json:"response_timeout"`
+	Retries             int      `json:"retries"`
+	RetryOn             []int    `json:"retry_on"`
+	RetryOnNetworkError bool     `json:"retry_on_network_error"`
+	Backoff             Duration `json:"backoff"`
+	MaxBackoff          Duration `json:"max_backoff"`
+	FollowRedirects     *bool    `json:"follow_redirects"`
+	MaxRedirects        int      `json:"max_redirects"`
+	KeepAuthorization   bool     `json:"keep_authorization"`
+	CACert              string   `json:"ca_cert"`
+	ClientCert          string   `json:"client_cert"`
+	ClientKey           string   `json:"client_key"`
+	TLSMinVersion       string   `json:"tls_min_version"`
+	TLSServerName       string   `json:"tls_server_name"`
+	Insecure            bool     `json:"insecure"`
+	Proxy               string   `json:"proxy"`
+	NoProxy             string   `json:"no_proxy"`
+	CookieJar           string   `json:"cookie_jar"`
+	Session             string   `json:"session"`
+	StoreTTL            Duration `json:"store_ttl"`
 }
 
 // DoFile is the representation of file.do
 type DoFile struct {
-	Let Let `json:"let"`
-	Do  Do  `json:"do"`
+	Let      Let             `json:"let"`
+	Do       Do              `json:"do"`
+	Options  Options         `json:"options"`
+	Capture  []Capture       `json:"capture,omitempty"`
+	Expect   []Expectation   `json:"expect,omitempty"`
+	Snapshot *SnapshotConfig `json:"snapshot,omitempty"`
+}
+
+// Capture defines a value of the response kept in a variable for the next requests.
+// The expression is the json path, the header, the cookie or the regex read by the source.
+type Capture struct {
+	Name       string `json:"name"`
+	Source     string `json:"source"`
+	Expression string `json:"expression"`
+}
+
+// Expectation defines an assertion about the response. The target is the header name or the
+// json path of the headers, json, contains and matches types.
+type Expectation struct {
+	Type   string      `json:"type"`
+	Target string      `json:"target,omitempty"`
+	Value  interface{} `json:"value"`
+}
+
+// Assertion defines the result of checking an expectation against the response
+type Assertion struct {
+	Type     string      `json:"type"`
+	Target   string      `json:"target,omitempty"`
+	Expected interface{} `json:"expected"`
+	Actual   interface{} `json:"actual"`
+	Passed   bool        `json:"passed"`
+	Message  string      `json:"message,omitempty"`
+}
+
+// SnapshotConfig defines the headers kept in the snapshot of the response, and the json paths
+// of the body whose values are ignored because they change on each request
+type SnapshotConfig struct {
+	Headers []string `json:"headers"`
+	Ignore  []string `json:"ignore"`
+}
+
+// SnapshotResult defines the comparison of the response with the snapshot recorded in its golden file
+type SnapshotResult struct {
+	Path    string `json:"path"`
+	Updated bool   `json:"updated"`
+	Passed  bool   `json:"passed"`
+	Diff    string `json:"diff,omitempty"`
+}
+
+// Request defines the request sent to the server, after replacing the params,
+// encoding the query and following the redirects
+type Request struct {
+	Method    string                 `json:"method"`
+	URL       string                 `json:"url"`
+	Headers   map[string]interface{} `json:"headers"`
+	Body      string                 `json:"body"`
+	Form      []FormField            `json:"form,omitempty"`
+	Redirects []Redirect             `json:"redirects"`
+	StartedAt time.Time              `json:"started_at"`
+	Attempts  []Attempt              `json:"attempts"`
+}
+
+// Masked returns a copy of the request with the secrets masked in its url, headers, body and form
+func (r Request) Masked(secrets []string) Request {
+	r.URL = MaskText(r.URL, secrets)
+	r.Headers, _ = MaskSecrets(r.Headers, secrets).(map[string]interface{})
+	r.Body = MaskText(r.Body, secrets)
+
+	if r.Form != nil {
+		form := make([]FormField, len(r.Form))
+		for i, field := range r.Form {
+			field.Value = MaskText(field.Value, secrets)
+			form[i] = field
+		}
+		r.Form = form
+	}
+
+	return r
+}
+
+// FormField defines a part of a multipart body, the file name is the path of the file sent
+type FormField struct {
+	Name     string `json:"name"`
+	Value    string `json:"value,omitempty"`
+	FileName string `json:"file_name,omitempty"`
+}
+
+// Attempt defines a try to send the request, the last one is the request returned
+type Attempt struct {
+	StartedAt  time.Time `json:"started_at"`
+	Duration   float64   `json:"duration"`
+	StatusCode int       `json:"status_code"`
+	Error      *string   `json:"error"`
+	Backoff    float64   `json:"backoff"`
+}
+
+// Redirect defines a redirect response followed while doing a request
+type Redirect struct {
+	StatusCode int    `json:"status_code"`
+	URL        string `json:"url"`
+	Location   string `json:"location"`
 }
 
 // Response defines the response of a request
@@ -48,13 +331,158 @@ type Response struct {
 	StatusCode int                    `json:"status_code"`
 	Body       string                 `json:"body"`
 	Headers    map[string]interface{} `json:"headers"`
+	Proto      string                 `json:"proto"`
+	Cookies    []Cookie               `json:"cookies"`
+	TLS        *TLS                   `json:"tls"`
+	Timings    Timings                `json:"timings"`
+}
+
+// Masked returns a copy of the response with the secrets masked in its body, headers and cookies
+func (r Response) Masked(secrets []string) Response {
+	r.Body = MaskText(r.Body, secrets)
+	r.Headers, _ = MaskSecrets(r.Headers, secrets).(map[string]interface{})
+
+	if r.Cookies != nil {
+		cookies := make([]Cookie, len(r.Cookies))
+		for i, cookie := range r.Cookies {
+			cookie.Value = MaskText(cookie.Value, secrets)
+			cookies[i] = cookie
+		}
+		r.Cookies = cookies
+	}
+
+	return r
+}
+
+// Cookie defines a cookie set by a response or kept in a cookie jar
+type Cookie struct {
+	Name     string     `json:"name"`
+	Value    string     `json:"value"`
+	Domain   string     `json:"domain"`
+	Path     string     `json:"path"`
+	Expires  *time.Time `json:"expires"`
+	MaxAge   int        `json:"max_age"`
+	Secure   bool       `json:"secure"`
+	HttpOnly bool       `json:"http_only"`
+	SameSite string     `json:"same_site"`
+	HostOnly bool       `json:"host_only"`
+}
+
+// TLS defines the connection negotiated with the server, nil when it is not encrypted
+type TLS struct {
+	Version         string      `json:"version"`
+	CipherSuite     string      `json:"cipher_suite"`
+	ServerName      string      `json:"server_name"`
+	PeerCertificate Certificate `json:"peer_certificate"`
+}
+
+// Certificate defines a summary of a x509 certificate
+type Certificate struct {
+	Subject   string    `json:"subject"`
+	Issuer    string    `json:"issuer"`
+	DNSNames  []string  `json:"dns_names"`
+	NotBefore time.Time `json:"not_before"`
+	NotAfter  time.Time `json:"not_after"`
+}
+
+// Timings defines the milliseconds spent in each phase of the last request sent,
+// -1 when the phase did not happen
+type Timings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	SSL     float64 `json:"ssl"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
 }
 
 // CommandLineOutput defines the output of the command line
 type CommandLineOutput struct {
-	DoFile   DoFile    `json:"do_file"`
-	Response *Response `json:"response"`
-	Error    *string   `json:"error"`
+	DoFile     DoFile          `json:"do_file"`
+	Request    *Request        `json:"request"`
+	Response   *Response       `json:"response"`
+	Captures   Map             `json:"captures,omitempty"`
+	Assertions []Assertion     `json:"assertions,omitempty"`
+	Snapshot   *SnapshotResult `json:"snapshot,omitempty"`
+	Error      *string         `json:"error"`
+	// Secrets are the values read from the store or captured, masked in the output
+	Secrets []string `json:"-"`
+}
+
+// Masked returns a copy of the output with the secrets masked in the do file, the request, the
+// response, the assertions and the snapshot diff, and with the captured values masked because
+// they are kept in the store
+func (c CommandLineOutput) Masked() CommandLineOutput {
+	c.DoFile.Let.Variables, _ = MaskSecrets(c.DoFile.Let.Variables, c.Secrets).(map[string]interface{})
+	c.DoFile.Do.URL = String(MaskText(string(c.DoFile.Do.URL), c.Secrets))
+	c.DoFile.Do.Params, _ = MaskSecrets(c.DoFile.Do.Params, c.Secrets).(Map)
+	c.DoFile.Do.Query, _ = MaskSecrets(c.DoFile.Do.Query, c.Secrets).(Map)
+	c.DoFile.Do.Headers, _ = MaskSecrets(c.DoFile.Do.Headers, c.Secrets).(Map)
+	c.DoFile.Do.Body = MaskSecrets(c.DoFile.Do.Body, c.Secrets)
+
+	if c.DoFile.Expect != nil {
+		expect := make([]Expectation, len(c.DoFile.Expect))
+		for i, expectation := range c.DoFile.Expect {
+			expectation.Value = MaskSecrets(expectation.Value, c.Secrets)
+			expect[i] = expectation
+		}
+		c.DoFile.Expect = expect
+	}
+
+	if c.Request != nil {
+		request := c.Request.Masked(c.Secrets)
+		c.Request = &request
+	}
+
+	if c.Response != nil {
+		response := c.Response.Masked(c.Secrets)
+		c.Response = &response
+	}
+
+	if c.Assertions != nil {
+		assertions := make([]Assertion, len(c.Assertions))
+		for i, assertion := range c.Assertions {
+			assertion.Expected = MaskSecrets(assertion.Expected, c.Secrets)
+			assertion.Actual = MaskSecrets(assertion.Actual, c.Secrets)
+			assertion.Message = MaskText(assertion.Message, c.Secrets)
+			assertions[i] = assertion
+		}
+		c.Assertions = assertions
+	}
+
+	if c.Snapshot != nil {
+		snapshot := *c.Snapshot
+		snapshot.Diff = MaskText(snapshot.Diff, c.Secrets)
+		c.Snapshot = &snapshot
+	}
+
+	if c.Captures != nil {
+		captures := make(Map, len(c.Captures))
+		for key, value := range c.Captures {
+			captures[key] = Mask(fmt.Sprintf("%v", value))
+		}
+		c.Captures = captures
+	}
+
+	return c
+}
+
+// MarshalJSON returns the output with the secrets masked
+func (c CommandLineOutput) MarshalJSON() ([]byte, error) {
+	type output CommandLineOutput
+	return json.Marshal(output(c.Masked()))
+}
+
+// HasFailedAssertions returns true when an assertion of the response did not pass
+func (c CommandLineOutput) HasFailedAssertions() bool {
+	for _, assertion := range c.Assertions {
+		if !assertion.Passed {
+			return true
+		}
+	}
+
+	return false
 }
 
 // MarshalIndent returns the JSON representation of CommandLineOutput
@@ -63,6 +491,51 @@ func (c CommandLineOutput) MarshalIndent() string {
 	return string(value)
 }
 
+// CommandLineOutputs defines the output of the command line when it sends several files
+type CommandLineOutputs []CommandLineOutput
+
+// MarshalIndent returns the JSON representation of CommandLineOutputs
+func (c CommandLineOutputs) MarshalIndent() string {
+	value, _ := json.MarshalIndent(c, "", "   ")
+	return string(value)
+}
+
+// TestResult defines the result of sending a do file with the test command
+type TestResult struct {
+	Filename  string            `json:"filename"`
+	StartedAt time.Time         `json:"started_at"`
+	Duration  float64           `json:"duration"`
+	Output    CommandLineOutput `json:"output"`
+}
+
+// Passed returns true when the request was sent, every assertion passed and the response
+// matches its snapshot
+func (t TestResult) Passed() bool {
+	snapshotPassed := t.Output.Snapshot == nil || t.Output.Snapshot.Passed
+	return t.Output.Error == nil && !t.Output.HasFailedAssertions() && snapshotPassed
+}
+
+// Failures returns the error, the messages of the assertions that did not pass and the diff
+// of the snapshot when the response does not match it
+func (t TestResult) Failures() []string {
+	failures := make([]string, 0)
+	if t.Output.Error != nil {
+		failures = append(failures, *t.Output.Error)
+	}
+
+	for _, assertion := range t.Output.Assertions {
+		if !assertion.Passed {
+			failures = append(failures, assertion.Message)
+		}
+	}
+
+	if t.Output.Snapshot != nil && !t.Output.Snapshot.Passed {
+		failures = append(failures, "response does not match the snapshot "+t.Output.Snapshot.Path+":\n"+strings.TrimSuffix(t.Output.Snapshot.Diff, "\n"))
+	}
+
+	return failures
+}
+
 // Sentence defines a key-value pair
 type Sentence struct {
 	Key   string